@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
 	"github.com/bxcodec/go-clean-arch/internal/repository/qdrant"
 	"log"
 	"net/url"
@@ -17,16 +19,28 @@ import (
 	mysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
 
 	"github.com/bxcodec/go-clean-arch/article"
+	"github.com/bxcodec/go-clean-arch/author"
 	"github.com/bxcodec/go-clean-arch/bmi"
+	"github.com/bxcodec/go-clean-arch/comment"
+	"github.com/bxcodec/go-clean-arch/internal/embedding"
 	"github.com/bxcodec/go-clean-arch/internal/rest"
 	"github.com/bxcodec/go-clean-arch/internal/rest/middleware"
+	"github.com/bxcodec/go-clean-arch/internal/workers"
 	"github.com/joho/godotenv"
-	client "github.com/qdrant/go-client/qdrant"
 )
 
 const (
 	defaultTimeout = 30
 	defaultAddress = ":9090"
+
+	defaultArticleCollection = "articles"
+
+	defaultTrashRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = time.Hour
+
+	defaultPublishInterval = time.Minute
+
+	defaultViewFlushInterval = 10 * time.Second
 )
 
 func init() {
@@ -46,11 +60,6 @@ func main() {
 	qdrantHost := os.Getenv("QDRANT_HOST")
 	qdrantApiKey := os.Getenv("QDRANT_API_KEY")
 
-	cfg := &client.Config{
-		Host:   qdrantHost,
-		APIKey: qdrantApiKey,
-	}
-
 	collectionName := os.Getenv("QDRANT_COLLECTION_NAME")
 
 	connection := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPass, dbHost, dbPort, dbName)
@@ -88,11 +97,52 @@ func main() {
 
 	// Prepare Repository
 	authorRepo := mysqlRepo.NewAuthorRepository(dbConn)
-	articleRepo := mysqlRepo.NewArticleRepository(dbConn)
+	cursors := newCursorCodec()
+	articleRepo := mysqlRepo.NewArticleRepository(dbConn, cursors)
+	categoryRepo := mysqlRepo.NewCategoryRepository(dbConn)
+	searchRepo := mysqlRepo.NewSearchRepository(dbConn, cursors)
+	commentRepo := mysqlRepo.NewCommentRepository(dbConn, cursors)
+	tagRepo := mysqlRepo.NewTagRepository(dbConn)
+	viewRepo := mysqlRepo.NewViewRepository(dbConn)
+	viewCounter := workers.NewViewCounter(viewRepo, envDuration("VIEW_DEDUP_WINDOW", workers.DefaultViewDedupWindow))
 
 	// Build service layer
-	svc := article.NewService(articleRepo, authorRepo)
+	var articleOpts []article.Option
+	if os.Getenv("AUTHOR_FAILURE_MODE") == "partial" {
+		articleOpts = append(articleOpts, article.WithAuthorFailureMode(article.PartialPage))
+	}
+	switch os.Getenv("ARTICLE_TITLE_POLICY") {
+	case "suffix":
+		articleOpts = append(articleOpts, article.WithTitlePolicy(article.SuffixDuplicateTitle))
+	case "per_author":
+		articleOpts = append(articleOpts, article.WithTitlePolicy(article.DuplicateTitlePerAuthor))
+	}
+	articleQdrantRepo, err := qdrantrepo.NewArticleRepository(qdrantHost, qdrantApiKey, articleCollectionName(),
+		embedding.NewHashingEmbedder(embedding.DefaultDimension))
+	if err != nil {
+		log.Fatal("Failed to create Qdrant repository:", err)
+	}
+	err = articleQdrantRepo.CreateCollection(context.Background())
+	if err != nil {
+		log.Fatal("Failed to create the articles collection:", err)
+	}
+	articleOpts = append(articleOpts, article.WithSemanticIndex(articleQdrantRepo), article.WithTags(tagRepo),
+		article.WithViews(viewCounter, viewRepo))
+
+	svc := article.NewService(articleRepo, authorRepo, categoryRepo, searchRepo, articleOpts...)
 	rest.NewArticleHandler(e, svc)
+	rest.NewCategoryHandler(e, svc)
+	rest.NewRevisionHandler(e, svc)
+	rest.NewTagHandler(e, svc)
+	rest.NewTransferHandler(e, svc)
+	rest.NewFeedHandler(e, svc, envInt("FEED_ITEMS", rest.DefaultFeedItems))
+
+	commentSvc := comment.NewService(commentRepo, articleRepo,
+		comment.WithEditWindow(envDuration("COMMENT_EDIT_WINDOW", comment.DefaultEditWindow)))
+	rest.NewCommentHandler(e, commentSvc)
+
+	authorSvc := author.NewService(authorRepo, articleRepo)
+	rest.NewAuthorHandler(e, authorSvc)
 
 	bmiQdrantRepo, err := qdrantrepo.NewBMIRepository(qdrantHost, qdrantApiKey, collectionName)
 	if err != nil {
@@ -100,12 +150,112 @@ func main() {
 	}
 
 	bmiRepo := mysqlRepo.NewBMIRepository(dbConn)
-	bmiService := bmi.NewServices(bmiRepo, bmiQdrantRepo)
+	bmiOpts := []bmi.Option{bmi.WithClassifiers(newBMIClassifiers())}
+	if path := os.Getenv("BMI_GROWTH_REFERENCE_FILE"); path != "" {
+		// the CDC's monthly BMI-for-age table is finer than the embedded yearly one
+		growth, err := bmi.LoadGrowthReference(path)
+		if err != nil {
+			log.Fatal("failed to load the BMI growth reference ", err)
+		}
+		bmiOpts = append(bmiOpts, bmi.WithGrowthReference(growth))
+	}
+	bmiService := bmi.NewServices(bmiRepo, bmiQdrantRepo, bmiOpts...)
 	rest.NewBmiHandler(e, bmiService)
 
+	// Purge the trash, publish the scheduled articles and store the views in the background
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	purger := workers.NewPurger(svc, bmiService, envDuration("TRASH_RETENTION", defaultTrashRetention))
+	go purger.Run(workerCtx, envDuration("PURGE_INTERVAL", defaultPurgeInterval))
+	scheduler := workers.NewScheduler(svc)
+	go scheduler.Run(workerCtx, envDuration("PUBLISH_INTERVAL", defaultPublishInterval))
+	go viewCounter.Run(workerCtx, envDuration("VIEW_FLUSH_INTERVAL", defaultViewFlushInterval))
+
 	address := os.Getenv("SERVER_ADDRESS")
 	if address == "" {
 		address = defaultAddress
 	}
 	log.Fatal(e.Start(address))
 }
+
+// articleCollectionName is the Qdrant collection the articles are embedded into
+func articleCollectionName() string {
+	name := os.Getenv("QDRANT_ARTICLE_COLLECTION_NAME")
+	if name == "" {
+		name = defaultArticleCollection
+	}
+	return name
+}
+
+// envDuration reads a duration such as "720h" from the environment, falling back to def
+func envDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("failed to parse %s, using default %s", key, def)
+		return def
+	}
+	return d
+}
+
+// envInt reads a positive number from the environment, falling back to def
+func envInt(key string, def int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		log.Printf("failed to parse %s, using default %d", key, def)
+		return def
+	}
+	return n
+}
+
+// newBMIClassifiers gathers the shipped BMI classification schemes and the custom ones of the
+// BMI_SCHEMES_FILE JSON file, BMI_DEFAULT_SCHEME names the one used when the client picks none
+func newBMIClassifiers() *bmi.Classifiers {
+	var custom []bmi.Scheme
+	if path := os.Getenv("BMI_SCHEMES_FILE"); path != "" {
+		schemes, err := bmi.LoadSchemes(path)
+		if err != nil {
+			log.Fatal("failed to load the BMI schemes ", err)
+		}
+		custom = schemes
+	}
+	defaultScheme := os.Getenv("BMI_DEFAULT_SCHEME")
+	if defaultScheme == "" {
+		defaultScheme = bmi.DefaultScheme
+	}
+	classifiers, err := bmi.NewClassifiers(defaultScheme, custom...)
+	if err != nil {
+		log.Fatal("invalid BMI schemes ", err)
+	}
+	return classifiers
+}
+
+// newCursorCodec signs the paging cursors with CURSOR_SECRET. While rotating, the former secret
+// goes to CURSOR_PREVIOUS_SECRET and its cursors are accepted until CURSOR_PREVIOUS_SECRET_UNTIL (RFC3339)
+func newCursorCodec() *repository.CursorCodec {
+	secret := []byte(os.Getenv("CURSOR_SECRET"))
+	if len(secret) == 0 {
+		log.Println("CURSOR_SECRET is not set, using a random one: cursors won't survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal("failed to generate the cursor secret ", err)
+		}
+	}
+
+	var retired []repository.CursorKey
+	if previous := os.Getenv("CURSOR_PREVIOUS_SECRET"); previous != "" {
+		until, err := time.Parse(time.RFC3339, os.Getenv("CURSOR_PREVIOUS_SECRET_UNTIL"))
+		if err != nil {
+			log.Fatal("CURSOR_PREVIOUS_SECRET_UNTIL must be an RFC3339 time ", err)
+		}
+		retired = append(retired, repository.CursorKey{Secret: []byte(previous), NotAfter: until})
+	}
+	return repository.NewCursorCodec(secret, retired...)
+}
@@ -0,0 +1,99 @@
+package article
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// diffLines computes the line-level diff turning from into to, from the longest common subsequence of their lines.
+// The subsequence is found with Hirschberg's algorithm, in space linear in the number of lines
+func diffLines(from, to string) []domain.DiffLine {
+	a, b := splitLines(from), splitLines(to)
+	return diff(a, b, make([]domain.DiffLine, 0, max(len(a), len(b))))
+}
+
+// diff appends the edits turning a into b to res
+func diff(a, b []string, res []domain.DiffLine) []domain.DiffLine {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		res = append(res, domain.DiffLine{Op: domain.DiffEqual, Text: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	suffix := a[len(a)-n:]
+	a, b = a[:len(a)-n], b[:len(b)-n]
+
+	switch {
+	case len(a) == 0:
+		res = appendLines(res, domain.DiffInsert, b)
+	case len(b) == 0:
+		res = appendLines(res, domain.DiffDelete, a)
+	case len(a) == 1:
+		k := slices.Index(b, a[0])
+		if k < 0 {
+			res = append(res, domain.DiffLine{Op: domain.DiffDelete, Text: a[0]})
+			res = appendLines(res, domain.DiffInsert, b)
+			break
+		}
+		res = appendLines(res, domain.DiffInsert, b[:k])
+		res = append(res, domain.DiffLine{Op: domain.DiffEqual, Text: a[0]})
+		res = appendLines(res, domain.DiffInsert, b[k+1:])
+	default:
+		// split b where the halves of a share the longest common subsequence with its parts
+		mid := len(a) / 2
+		head, tail := lcsLengths(a[:mid], b, false), lcsLengths(a[mid:], b, true)
+		k := 0
+		for j := range head {
+			if head[j]+tail[j] > head[k]+tail[k] {
+				k = j
+			}
+		}
+		res = diff(a[:mid], b[:k], res)
+		res = diff(a[mid:], b[k:], res)
+	}
+	return appendLines(res, domain.DiffEqual, suffix)
+}
+
+// lcsLengths are the lengths of the longest common subsequence of a with b[:j], for every j.
+// Reversed, they're those of a with b[j:]
+func lcsLengths(a, b []string, reversed bool) []int {
+	at := func(lines []string, i int) string {
+		if reversed {
+			return lines[len(lines)-1-i]
+		}
+		return lines[i]
+	}
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if at(a, i) == at(b, j) {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	if reversed {
+		slices.Reverse(prev)
+	}
+	return prev
+}
+
+func appendLines(res []domain.DiffLine, op domain.DiffOp, lines []string) []domain.DiffLine {
+	for _, line := range lines {
+		res = append(res, domain.DiffLine{Op: op, Text: line})
+	}
+	return res
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
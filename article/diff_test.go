@@ -0,0 +1,62 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestDiffLines(t *testing.T) {
+	assert.Equal(t, []domain.DiffLine{
+		{Op: domain.DiffDelete, Text: "a"},
+		{Op: domain.DiffEqual, Text: "b"},
+		{Op: domain.DiffDelete, Text: "c"},
+		{Op: domain.DiffInsert, Text: "d"},
+		{Op: domain.DiffEqual, Text: "e"},
+		{Op: domain.DiffInsert, Text: "f"},
+	}, diffLines("a\nb\nc\ne", "b\nd\ne\nf"))
+	assert.Empty(t, diffLines("", ""))
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	const n = 10000
+	from, to := make([]string, 0, n), make([]string, 0, n)
+	kept := 0
+	for i := 0; i < n; i++ {
+		line := fmt.Sprintf("line %d", i)
+		from = append(from, line)
+		switch i % 7 {
+		case 0:
+			to = append(to, "changed "+line)
+		case 3:
+			to = append(to, line, "added "+line)
+			kept++
+		default:
+			to = append(to, line)
+			kept++
+		}
+	}
+
+	res := diffLines(strings.Join(from, "\n"), strings.Join(to, "\n"))
+
+	var gotFrom, gotTo []string
+	equal := 0
+	for _, line := range res {
+		if line.Op != domain.DiffInsert {
+			gotFrom = append(gotFrom, line.Text)
+		}
+		if line.Op != domain.DiffDelete {
+			gotTo = append(gotTo, line.Text)
+		}
+		if line.Op == domain.DiffEqual {
+			equal++
+		}
+	}
+	assert.Equal(t, from, gotFrom)
+	assert.Equal(t, to, gotTo)
+	assert.Equal(t, kept, equal)
+}
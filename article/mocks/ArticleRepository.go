@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/bxcodec/go-clean-arch/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -14,17 +15,17 @@ type ArticleRepository struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *ArticleRepository) Delete(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, deletedAt
+func (_m *ArticleRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	ret := _m.Called(ctx, id, deletedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,36 +33,73 @@ func (_m *ArticleRepository) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
-// Fetch provides a mock function with given fields: ctx, cursor, num
-func (_m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Article, string, error) {
-	ret := _m.Called(ctx, cursor, num)
+// Fetch provides a mock function with given fields: ctx, q
+func (_m *ArticleRepository) Fetch(ctx context.Context, q domain.ArticleQuery) ([]domain.Article, domain.Page, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Fetch")
 	}
 
 	var r0 []domain.Article
-	var r1 string
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ArticleQuery) ([]domain.Article, domain.Page, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ArticleQuery) []domain.Article); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Article)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ArticleQuery) domain.Page); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.ArticleQuery) error); ok {
+		r2 = rf(ctx, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FetchByCategory provides a mock function with given fields: ctx, categoryID, q
+func (_m *ArticleRepository) FetchByCategory(ctx context.Context, categoryID int64, q domain.ArticleQuery) ([]domain.Article, domain.Page, error) {
+	ret := _m.Called(ctx, categoryID, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchByCategory")
+	}
+
+	var r0 []domain.Article
+	var r1 domain.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.Article, string, error)); ok {
-		return rf(ctx, cursor, num)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.ArticleQuery) ([]domain.Article, domain.Page, error)); ok {
+		return rf(ctx, categoryID, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.Article); ok {
-		r0 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.ArticleQuery) []domain.Article); ok {
+		r0 = rf(ctx, categoryID, q)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Article)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) string); ok {
-		r1 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.ArticleQuery) domain.Page); ok {
+		r1 = rf(ctx, categoryID, q)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(domain.Page)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
-		r2 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, domain.ArticleQuery) error); ok {
+		r2 = rf(ctx, categoryID, q)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -69,6 +107,96 @@ func (_m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64
 	return r0, r1, r2
 }
 
+// FetchDue provides a mock function with given fields: ctx, now
+func (_m *ArticleRepository) FetchDue(ctx context.Context, now time.Time) ([]domain.Article, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDue")
+	}
+
+	var r0 []domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Article, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Article); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Article)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRevisions provides a mock function with given fields: ctx, articleID
+func (_m *ArticleRepository) FetchRevisions(ctx context.Context, articleID int64) ([]domain.Revision, error) {
+	ret := _m.Called(ctx, articleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRevisions")
+	}
+
+	var r0 []domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Revision, error)); ok {
+		return rf(ctx, articleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Revision); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchTrash provides a mock function with given fields: ctx
+func (_m *ArticleRepository) FetchTrash(ctx context.Context) ([]domain.Article, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTrash")
+	}
+
+	var r0 []domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Article, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Article); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Article)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *ArticleRepository) GetByID(ctx context.Context, id int64) (domain.Article, error) {
 	ret := _m.Called(ctx, id)
@@ -97,6 +225,64 @@ func (_m *ArticleRepository) GetByID(ctx context.Context, id int64) (domain.Arti
 	return r0, r1
 }
 
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *ArticleRepository) GetByIDs(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]domain.Article, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []domain.Article); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Article)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBySlug provides a mock function with given fields: ctx, slug
+func (_m *ArticleRepository) GetBySlug(ctx context.Context, slug string) (domain.Article, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Article, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Article); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.Article)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByTitle provides a mock function with given fields: ctx, title
 func (_m *ArticleRepository) GetByTitle(ctx context.Context, title string) (domain.Article, error) {
 	ret := _m.Called(ctx, title)
@@ -125,6 +311,108 @@ func (_m *ArticleRepository) GetByTitle(ctx context.Context, title string) (doma
 	return r0, r1
 }
 
+// GetRevision provides a mock function with given fields: ctx, id
+func (_m *ArticleRepository) GetRevision(ctx context.Context, id int64) (domain.Revision, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Revision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Revision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Revision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *ArticleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *ArticleRepository) Restore(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SlugExists provides a mock function with given fields: ctx, slug, exceptID
+func (_m *ArticleRepository) SlugExists(ctx context.Context, slug string, exceptID int64) (bool, error) {
+	ret := _m.Called(ctx, slug, exceptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SlugExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, slug, exceptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, slug, exceptID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, slug, exceptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Store provides a mock function with given fields: ctx, a
 func (_m *ArticleRepository) Store(ctx context.Context, a *domain.Article) error {
 	ret := _m.Called(ctx, a)
@@ -143,6 +431,34 @@ func (_m *ArticleRepository) Store(ctx context.Context, a *domain.Article) error
 	return r0
 }
 
+// TitleExists provides a mock function with given fields: ctx, scope, title
+func (_m *ArticleRepository) TitleExists(ctx context.Context, scope int64, title string) (bool, error) {
+	ret := _m.Called(ctx, scope, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TitleExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, scope, title)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, scope, title)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, scope, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, ar
 func (_m *ArticleRepository) Update(ctx context.Context, ar *domain.Article) error {
 	ret := _m.Called(ctx, ar)
@@ -161,6 +477,24 @@ func (_m *ArticleRepository) Update(ctx context.Context, ar *domain.Article) err
 	return r0
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, ar, from
+func (_m *ArticleRepository) UpdateStatus(ctx context.Context, ar *domain.Article, from domain.ArticleStatus) error {
+	ret := _m.Called(ctx, ar, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Article, domain.ArticleStatus) error); ok {
+		r0 = rf(ctx, ar, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewArticleRepository creates a new instance of ArticleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewArticleRepository(t interface {
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CategoryRepository is an autogenerated mock type for the CategoryRepository type
+type CategoryRepository struct {
+	mock.Mock
+}
+
+// AddArticle provides a mock function with given fields: ctx, articleID, categoryID
+func (_m *CategoryRepository) AddArticle(ctx context.Context, articleID int64, categoryID int64) error {
+	ret := _m.Called(ctx, articleID, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArticle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, articleID, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (_m *CategoryRepository) Fetch(ctx context.Context) ([]domain.Category, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 []domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Category, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByArticleIDs provides a mock function with given fields: ctx, articleIDs
+func (_m *CategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]domain.Category, error) {
+	ret := _m.Called(ctx, articleIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByArticleIDs")
+	}
+
+	var r0 map[int64][]domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]domain.Category, error)); ok {
+		return rf(ctx, articleIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]domain.Category); ok {
+		r0 = rf(ctx, articleIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]domain.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, articleIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) GetByID(ctx context.Context, id int64) (domain.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveArticle provides a mock function with given fields: ctx, articleID, categoryID
+func (_m *CategoryRepository) RemoveArticle(ctx context.Context, articleID int64, categoryID int64) error {
+	ret := _m.Called(ctx, articleID, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveArticle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, articleID, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCategoryRepository creates a new instance of CategoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCategoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CategoryRepository {
+	mock := &CategoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
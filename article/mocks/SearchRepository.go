@@ -0,0 +1,66 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchRepository is an autogenerated mock type for the SearchRepository type
+type SearchRepository struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, q
+func (_m *SearchRepository) Search(ctx context.Context, q domain.SearchQuery) ([]domain.SearchHit, domain.Page, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) ([]domain.SearchHit, domain.Page, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) []domain.SearchHit); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SearchQuery) domain.Page); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.SearchQuery) error); ok {
+		r2 = rf(ctx, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewSearchRepository creates a new instance of SearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchRepository {
+	mock := &SearchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SemanticRepository is an autogenerated mock type for the SemanticRepository type
+type SemanticRepository struct {
+	mock.Mock
+}
+
+// Index provides a mock function with given fields: ctx, ar
+func (_m *SemanticRepository) Index(ctx context.Context, ar domain.Article) error {
+	ret := _m.Called(ctx, ar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Index")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Article) error); ok {
+		r0 = rf(ctx, ar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Remove provides a mock function with given fields: ctx, id
+func (_m *SemanticRepository) Remove(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, text, limit
+func (_m *SemanticRepository) Search(ctx context.Context, text string, limit int64) ([]domain.SearchHit, error) {
+	ret := _m.Called(ctx, text, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.SearchHit, error)); ok {
+		return rf(ctx, text, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.SearchHit); ok {
+		r0 = rf(ctx, text, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, text, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSemanticRepository creates a new instance of SemanticRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSemanticRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SemanticRepository {
+	mock := &SemanticRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
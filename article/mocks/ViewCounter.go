@@ -0,0 +1,29 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// ViewCounter is an autogenerated mock type for the ViewCounter type
+type ViewCounter struct {
+	mock.Mock
+}
+
+// Record provides a mock function with given fields: articleID, client
+func (_m *ViewCounter) Record(articleID int64, client string) {
+	_m.Called(articleID, client)
+}
+
+// NewViewCounter creates a new instance of ViewCounter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewViewCounter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ViewCounter {
+	mock := &ViewCounter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ViewRepository is an autogenerated mock type for the ViewRepository type
+type ViewRepository struct {
+	mock.Mock
+}
+
+// Trending provides a mock function with given fields: ctx, since, now, halfLife, num
+func (_m *ViewRepository) Trending(ctx context.Context, since time.Time, now time.Time, halfLife time.Duration, num int64) ([]domain.TrendingArticle, error) {
+	ret := _m.Called(ctx, since, now, halfLife, num)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trending")
+	}
+
+	var r0 []domain.TrendingArticle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Duration, int64) ([]domain.TrendingArticle, error)); ok {
+		return rf(ctx, since, now, halfLife, num)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Duration, int64) []domain.TrendingArticle); ok {
+		r0 = rf(ctx, since, now, halfLife, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrendingArticle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, time.Duration, int64) error); ok {
+		r1 = rf(ctx, since, now, halfLife, num)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewViewRepository creates a new instance of ViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewViewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ViewRepository {
+	mock := &ViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
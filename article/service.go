@@ -2,10 +2,12 @@ package article
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/bxcodec/go-clean-arch/domain"
 )
@@ -14,12 +16,24 @@ import (
 //
 //go:generate mockery --name ArticleRepository
 type ArticleRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) (res []domain.Article, nextCursor string, err error)
+	Fetch(ctx context.Context, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error)
+	FetchByCategory(ctx context.Context, categoryID int64, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]domain.Article, error)
 	GetByTitle(ctx context.Context, title string) (domain.Article, error)
+	GetBySlug(ctx context.Context, slug string) (domain.Article, error)
+	SlugExists(ctx context.Context, slug string, exceptID int64) (bool, error)
+	TitleExists(ctx context.Context, scope int64, title string) (bool, error)
 	Update(ctx context.Context, ar *domain.Article) error
+	UpdateStatus(ctx context.Context, ar *domain.Article, from domain.ArticleStatus) error
+	FetchDue(ctx context.Context, now time.Time) ([]domain.Article, error)
 	Store(ctx context.Context, a *domain.Article) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, deletedAt time.Time) error
+	Restore(ctx context.Context, id int64) error
+	FetchTrash(ctx context.Context) ([]domain.Article, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	FetchRevisions(ctx context.Context, articleID int64) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, id int64) (domain.Revision, error)
 }
 
 // AuthorRepository represent the author's repository contract
@@ -27,107 +41,500 @@ type ArticleRepository interface {
 //go:generate mockery --name AuthorRepository
 type AuthorRepository interface {
 	GetByID(ctx context.Context, id int64) (domain.Author, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]domain.Author, error)
 }
 
+// CategoryRepository represent the category's repository contract
+//
+//go:generate mockery --name CategoryRepository
+type CategoryRepository interface {
+	Fetch(ctx context.Context) ([]domain.Category, error)
+	GetByID(ctx context.Context, id int64) (domain.Category, error)
+	GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]domain.Category, error)
+	AddArticle(ctx context.Context, articleID, categoryID int64) error
+	RemoveArticle(ctx context.Context, articleID, categoryID int64) error
+}
+
+// TagRepository represent the tag's repository contract
+//
+//go:generate mockery --name TagRepository
+type TagRepository interface {
+	Suggest(ctx context.Context, prefix string, num int64) ([]domain.Tag, error)
+	GetByID(ctx context.Context, id int64) (domain.Tag, error)
+	GetByName(ctx context.Context, name string) (domain.Tag, error)
+	GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]string, error)
+	SetArticleTags(ctx context.Context, articleID int64, names []string) error
+	Rename(ctx context.Context, id int64, name string) error
+	Merge(ctx context.Context, fromID, intoID int64) error
+}
+
+// SearchRepository represent the article's search index contract
+//
+//go:generate mockery --name SearchRepository
+type SearchRepository interface {
+	Search(ctx context.Context, q domain.SearchQuery) (res []domain.SearchHit, page domain.Page, err error)
+}
+
+// SemanticRepository represent the article's vector index contract
+//
+//go:generate mockery --name SemanticRepository
+type SemanticRepository interface {
+	Index(ctx context.Context, ar domain.Article) error
+	Remove(ctx context.Context, id int64) error
+	Search(ctx context.Context, text string, limit int64) ([]domain.SearchHit, error)
+}
+
+// ViewCounter represent the counter the article views are recorded into
+//
+//go:generate mockery --name ViewCounter
+type ViewCounter interface {
+	Record(articleID int64, client string)
+}
+
+// ViewRepository represent the article's view statistics contract
+//
+//go:generate mockery --name ViewRepository
+type ViewRepository interface {
+	Trending(ctx context.Context, since, now time.Time, halfLife time.Duration, num int64) ([]domain.TrendingArticle, error)
+}
+
+// AuthorFailureMode decides what happens to a page of articles when some of their authors can't be loaded
+type AuthorFailureMode int
+
+const (
+	// FailPage fails the whole page, this is the default
+	FailPage AuthorFailureMode = iota
+	// PartialPage returns the articles with an empty author and reports domain.ErrPartialResult
+	PartialPage
+)
+
+// TitlePolicy decides what Store does with a title another article already has
+type TitlePolicy int
+
+const (
+	// RejectDuplicateTitle fails with domain.ErrConflict, this is the default
+	RejectDuplicateTitle TitlePolicy = iota
+	// SuffixDuplicateTitle numbers the title, a second "Hello" is stored as "Hello (2)"
+	SuffixDuplicateTitle
+	// DuplicateTitlePerAuthor lets the authors share titles, an author still can't use a title twice
+	DuplicateTitlePerAuthor
+)
+
+const (
+	// maxTitleAttempts bounds the numbered titles SuffixDuplicateTitle tries before giving up
+	maxTitleAttempts = 100
+	// maxTitleLength is how many characters the storage keeps of a title
+	maxTitleLength = 45
+)
+
 type Service struct {
-	articleRepo ArticleRepository
-	authorRepo  AuthorRepository
+	articleRepo  ArticleRepository
+	authorRepo   AuthorRepository
+	categoryRepo CategoryRepository
+	searchRepo   SearchRepository
+	semanticRepo SemanticRepository
+	tagRepo      TagRepository
+	viewCounter  ViewCounter
+	viewRepo     ViewRepository
+
+	authorFailureMode AuthorFailureMode
+	titlePolicy       TitlePolicy
+}
+
+// Option configures the optional behaviour of the article service
+type Option func(*Service)
+
+// WithAuthorFailureMode sets what Fetch does when some authors can't be loaded
+func WithAuthorFailureMode(mode AuthorFailureMode) Option {
+	return func(s *Service) {
+		s.authorFailureMode = mode
+	}
+}
+
+// WithTitlePolicy sets what Store does with duplicate titles. Moving from DuplicateTitlePerAuthor
+// to another policy only applies to the articles saved from then on
+func WithTitlePolicy(policy TitlePolicy) Option {
+	return func(s *Service) {
+		s.titlePolicy = policy
+	}
+}
+
+// WithSemanticIndex keeps the articles indexed in the vector index and enables SemanticSearch
+func WithSemanticIndex(sr SemanticRepository) Option {
+	return func(s *Service) {
+		s.semanticRepo = sr
+	}
+}
+
+// WithTags enables tagging the articles, the articles are read along with their tags
+func WithTags(tr TagRepository) Option {
+	return func(s *Service) {
+		s.tagRepo = tr
+	}
+}
+
+// WithViews records the views of the published articles into the counter and enables Trending
+func WithViews(vc ViewCounter, vr ViewRepository) Option {
+	return func(s *Service) {
+		s.viewCounter = vc
+		s.viewRepo = vr
+	}
 }
 
 // NewService will create a new article service object
-func NewService(a ArticleRepository, ar AuthorRepository) *Service {
-	return &Service{
-		articleRepo: a,
-		authorRepo:  ar,
+func NewService(a ArticleRepository, ar AuthorRepository, cr CategoryRepository, sr SearchRepository, opts ...Option) *Service {
+	s := &Service{
+		articleRepo:  a,
+		authorRepo:   ar,
+		categoryRepo: cr,
+		searchRepo:   sr,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-/*
-* In this function below, I'm using errgroup with the pipeline pattern
-* Look how this works in this package explanation
-* in godoc: https://godoc.org/golang.org/x/sync/errgroup#ex-Group--Pipeline
- */
+// fillAuthorDetails loads the authors of every article with a single query.
+// When some of them can't be loaded the page either fails or, in PartialPage mode,
+// the articles keep an empty author and domain.ErrPartialResult is reported
 func (a *Service) fillAuthorDetails(ctx context.Context, data []domain.Article) ([]domain.Article, error) {
-	g, ctx := errgroup.WithContext(ctx)
+	if len(data) == 0 {
+		return data, nil
+	}
+
 	// Get the author's id
 	mapAuthors := map[int64]domain.Author{}
-
+	authorIDs := make([]int64, 0)
 	for _, article := range data { //nolint
-		mapAuthors[article.Author.ID] = domain.Author{}
-	}
-	// Using goroutine to fetch the author's detail
-	chanAuthor := make(chan domain.Author)
-	for authorID := range mapAuthors {
-		authorID := authorID
-		g.Go(func() error {
-			res, err := a.authorRepo.GetByID(ctx, authorID)
-			if err != nil {
-				return err
-			}
-			chanAuthor <- res
-			return nil
-		})
-	}
-
-	go func() {
-		defer close(chanAuthor)
-		err := g.Wait()
-		if err != nil {
-			logrus.Error(err)
-			return
+		if _, ok := mapAuthors[article.Author.ID]; !ok {
+			mapAuthors[article.Author.ID] = domain.Author{}
+			authorIDs = append(authorIDs, article.Author.ID)
 		}
+	}
 
-	}()
-
-	for author := range chanAuthor {
-		if author != (domain.Author{}) {
-			mapAuthors[author.ID] = author
+	authors, err := a.authorRepo.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		if a.authorFailureMode != PartialPage {
+			return nil, err
 		}
+		logrus.Error(err)
+		return data, fmt.Errorf("%w: %s", domain.ErrPartialResult, "authors could not be loaded")
 	}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+	for _, author := range authors {
+		mapAuthors[author.ID] = author
 	}
 
 	// merge the author's data
+	missing := 0
 	for index, item := range data { //nolint
-		if a, ok := mapAuthors[item.Author.ID]; ok {
-			data[index].Author = a
+		author := mapAuthors[item.Author.ID]
+		if author.ID == 0 {
+			missing++
+			continue
 		}
+		data[index].Author = author
+	}
+
+	if missing == 0 {
+		return data, nil
+	}
+	if a.authorFailureMode != PartialPage {
+		return nil, fmt.Errorf("%w: author of %d articles does not exist", domain.ErrInternalServerError, missing)
+	}
+	return data, fmt.Errorf("%w: author of %d articles does not exist", domain.ErrPartialResult, missing)
+}
+
+func (a *Service) fillCategoryDetails(ctx context.Context, data []domain.Article) ([]domain.Article, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	articleIDs := make([]int64, 0, len(data))
+	for _, item := range data { //nolint
+		articleIDs = append(articleIDs, item.ID)
+	}
+
+	mapCategories, err := a.categoryRepo.GetByArticleIDs(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, item := range data { //nolint
+		data[index].Categories = mapCategories[item.ID]
 	}
 	return data, nil
 }
 
-func (a *Service) Fetch(ctx context.Context, cursor string, num int64) (res []domain.Article, nextCursor string, err error) {
-	res, nextCursor, err = a.articleRepo.Fetch(ctx, cursor, num)
+// fillTagDetails loads the tags of every article with a single query, when tagging is enabled
+func (a *Service) fillTagDetails(ctx context.Context, data []domain.Article) ([]domain.Article, error) {
+	if a.tagRepo == nil || len(data) == 0 {
+		return data, nil
+	}
+
+	articleIDs := make([]int64, 0, len(data))
+	for _, item := range data { //nolint
+		articleIDs = append(articleIDs, item.ID)
+	}
+
+	mapTags, err := a.tagRepo.GetByArticleIDs(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, item := range data { //nolint
+		data[index].Tags = mapTags[item.ID]
+	}
+	return data, nil
+}
+
+func (a *Service) Fetch(ctx context.Context, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error) {
+	q.Tags, err = normalizeTags(q.Tags)
 	if err != nil {
-		return nil, "", err
+		return nil, domain.Page{}, err
 	}
 
-	res, err = a.fillAuthorDetails(ctx, res)
+	res, page, err = a.articleRepo.Fetch(ctx, q)
 	if err != nil {
-		nextCursor = ""
+		return nil, domain.Page{}, err
+	}
+
+	res, err = a.fillDetails(ctx, res)
+	if err != nil && !errors.Is(err, domain.ErrPartialResult) {
+		page = domain.Page{}
 	}
 	return
 }
 
+// FetchByCategory will fetch the articles assigned to the given category
+func (a *Service) FetchByCategory(ctx context.Context, categoryID int64, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error) {
+	_, err = a.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return nil, domain.Page{}, err
+	}
+	q.Tags, err = normalizeTags(q.Tags)
+	if err != nil {
+		return nil, domain.Page{}, err
+	}
+
+	res, page, err = a.articleRepo.FetchByCategory(ctx, categoryID, q)
+	if err != nil {
+		return nil, domain.Page{}, err
+	}
+
+	res, err = a.fillDetails(ctx, res)
+	if err != nil && !errors.Is(err, domain.ErrPartialResult) {
+		page = domain.Page{}
+	}
+	return
+}
+
+// Search will look the terms up in the title and content of the articles, the most relevant first
+func (a *Service) Search(ctx context.Context, q domain.SearchQuery) (res []domain.SearchHit, page domain.Page, err error) {
+	if strings.TrimSpace(q.Terms) == "" {
+		return nil, domain.Page{}, fmt.Errorf("%w: the search is empty", domain.ErrBadParamInput)
+	}
+
+	res, page, err = a.searchRepo.Search(ctx, q)
+	if err != nil {
+		return nil, domain.Page{}, err
+	}
+
+	articles := make([]domain.Article, 0, len(res))
+	for _, hit := range res { //nolint
+		articles = append(articles, hit.Article)
+	}
+	articles, err = a.fillDetails(ctx, articles)
+	if err != nil && !errors.Is(err, domain.ErrPartialResult) {
+		return nil, domain.Page{}, err
+	}
+	for index := range res {
+		res[index].Article = articles[index]
+	}
+	return
+}
+
+// SemanticSearch will fetch the num articles closest in meaning to the text, the closest first
+func (a *Service) SemanticSearch(ctx context.Context, text string, num int64) (res []domain.SearchHit, err error) {
+	if a.semanticRepo == nil {
+		return nil, fmt.Errorf("%w: semantic search is not enabled", domain.ErrNotFound)
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: the search is empty", domain.ErrBadParamInput)
+	}
+
+	hits, err := a.semanticRepo.Search(ctx, text, num)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits { //nolint
+		ids = append(ids, hit.Article.ID)
+	}
+	articles, err := a.articleRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	mapArticles := make(map[int64]domain.Article, len(articles))
+	for _, ar := range articles { //nolint
+		mapArticles[ar.ID] = ar
+	}
+
+	// the index may still hold articles deleted or unpublished in between
+	res = make([]domain.SearchHit, 0, len(hits))
+	articles = make([]domain.Article, 0, len(hits))
+	for _, hit := range hits { //nolint
+		ar, ok := mapArticles[hit.Article.ID]
+		if !ok || ar.Status != domain.StatusPublished {
+			continue
+		}
+		res = append(res, domain.SearchHit{Article: ar, Score: hit.Score})
+		articles = append(articles, ar)
+	}
+
+	articles, err = a.fillDetails(ctx, articles)
+	if err != nil && !errors.Is(err, domain.ErrPartialResult) {
+		return nil, err
+	}
+	for index := range res {
+		res[index].Article = articles[index]
+	}
+	return
+}
+
+// index keeps the vector index in line with the stored article, only the published ones are searchable.
+// The database stays the source of truth, so a failure is only logged and the article is
+// searchable again once it's saved next time
+func (a *Service) index(ctx context.Context, ar domain.Article) {
+	if a.semanticRepo == nil {
+		return
+	}
+	if ar.Status != domain.StatusPublished {
+		a.unindex(ctx, ar.ID)
+		return
+	}
+	if err := a.semanticRepo.Index(ctx, ar); err != nil {
+		logrus.Errorf("failed to index article %d: %s", ar.ID, err)
+	}
+}
+
+// unindex drops the deleted article from the vector index, a failure is only logged
+func (a *Service) unindex(ctx context.Context, id int64) {
+	if a.semanticRepo == nil {
+		return
+	}
+	if err := a.semanticRepo.Remove(ctx, id); err != nil {
+		logrus.Errorf("failed to unindex article %d: %s", id, err)
+	}
+}
+
+// fillDetails fills the authors and categories of the articles, a domain.ErrPartialResult
+// is returned alongside the articles when only some details could be loaded
+func (a *Service) fillDetails(ctx context.Context, data []domain.Article) ([]domain.Article, error) {
+	data, authorErr := a.fillAuthorDetails(ctx, data)
+	if authorErr != nil && !errors.Is(authorErr, domain.ErrPartialResult) {
+		return nil, authorErr
+	}
+
+	data, err := a.fillCategoryDetails(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = a.fillTagDetails(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return data, authorErr
+}
+
+func (a *Service) fillOne(ctx context.Context, ar domain.Article) (domain.Article, error) {
+	resAuthor, err := a.authorRepo.GetByID(ctx, ar.Author.ID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	ar.Author = resAuthor
+
+	mapCategories, err := a.categoryRepo.GetByArticleIDs(ctx, []int64{ar.ID})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	ar.Categories = mapCategories[ar.ID]
+
+	list, err := a.fillTagDetails(ctx, []domain.Article{ar})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return list[0], nil
+}
+
 func (a *Service) GetByID(ctx context.Context, id int64) (res domain.Article, err error) {
 	res, err = a.articleRepo.GetByID(ctx, id)
 	if err != nil {
 		return
 	}
 
-	resAuthor, err := a.authorRepo.GetByID(ctx, res.Author.ID)
+	return a.fillOne(ctx, res)
+}
+
+// Update will update the article as long as nobody else changed it in between.
+// ar.Version must hold the version the caller last read, it's replaced by the new one
+func (a *Service) Update(ctx context.Context, ar *domain.Article) (err error) {
+	err = a.ensureAuthor(ctx, ar.Author.ID)
 	if err != nil {
-		return domain.Article{}, err
+		return
+	}
+
+	ar.Slug, err = a.uniqueSlug(ctx, ar.Title, ar.ID)
+	if err != nil {
+		return
 	}
-	res.Author = resAuthor
+	ar.TitleScope = a.titleScope(*ar)
+	summarize(ar)
+
+	ar.UpdatedAt = time.Now().Truncate(time.Second)
+	err = a.articleRepo.Update(ctx, ar)
+	if err != nil {
+		return
+	}
+
+	a.index(ctx, *ar)
 	return
 }
 
-func (a *Service) Update(ctx context.Context, ar *domain.Article) (err error) {
-	ar.UpdatedAt = time.Now()
-	return a.articleRepo.Update(ctx, ar)
+// GetBySlug will get the article by its current or one of its former slugs,
+// the Slug of the article tells whether the given one is outdated
+func (a *Service) GetBySlug(ctx context.Context, slug string) (res domain.Article, err error) {
+	res, err = a.articleRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return
+	}
+
+	return a.fillOne(ctx, res)
+}
+
+// uniqueSlug derives the slug from the title, a number is appended when another article
+// uses it or used it before. Titles without any letter to spell fall back on "article"
+func (a *Service) uniqueSlug(ctx context.Context, title string, articleID int64) (string, error) {
+	base := slugify(title)
+	if base == "" {
+		base = "article"
+	}
+
+	slug := base
+	for n := 2; n <= maxSlugAttempts; n++ {
+		exists, err := a.articleRepo.SlugExists(ctx, slug, articleID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return "", fmt.Errorf("%w: no slug left for %q", domain.ErrConflict, title)
 }
 
 func (a *Service) GetByTitle(ctx context.Context, title string) (res domain.Article, err error) {
@@ -136,32 +543,376 @@ func (a *Service) GetByTitle(ctx context.Context, title string) (res domain.Arti
 		return
 	}
 
-	resAuthor, err := a.authorRepo.GetByID(ctx, res.Author.ID)
+	return a.fillOne(ctx, res)
+}
+
+// Store will insert the article as a draft, the storage enforces the unique titles
+// and the title policy decides what happens to a duplicate one
+func (a *Service) Store(ctx context.Context, m *domain.Article) (err error) {
+	// every article starts as a draft, it goes public through Transition
+	m.Status = domain.StatusDraft
+	m.PublishAt = nil
+	return a.store(ctx, m)
+}
+
+// store saves the new article in the status it comes with, its timestamps default to now
+func (a *Service) store(ctx context.Context, m *domain.Article) (err error) {
+	err = a.ensureAuthor(ctx, m.Author.ID)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().Truncate(time.Second)
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = now
+	}
+	m.TitleScope = a.titleScope(*m)
+	summarize(m)
+
+	title := m.Title
+	for n := 2; ; n++ {
+		m.Slug, err = a.uniqueSlug(ctx, m.Title, 0)
+		if err != nil {
+			return
+		}
+
+		err = a.articleRepo.Store(ctx, m)
+		if !errors.Is(err, domain.ErrTitleTaken) || a.titlePolicy != SuffixDuplicateTitle || n > maxTitleAttempts {
+			return
+		}
+		m.Title = numberTitle(title, n)
+	}
+}
+
+// numberTitle appends the number to the title, the title is cut short so the numbered one still fits
+func numberTitle(title string, n int) string {
+	suffix := fmt.Sprintf(" (%d)", n)
+	runes := []rune(title)
+	if keep := maxTitleLength - len(suffix); len(runes) > keep {
+		title = strings.TrimRight(string(runes[:keep]), " ")
+	}
+	return title + suffix
+}
+
+// titleScope tells what the title of the article must be unique within under the title policy
+func (a *Service) titleScope(ar domain.Article) int64 {
+	if a.titlePolicy == DuplicateTitlePerAuthor {
+		return ar.Author.ID
+	}
+	return 0
+}
+
+// Transition will move the article to the given status of the workflow. publishAt is required
+// to schedule the article and must be in the future, it's rejected for any other status
+func (a *Service) Transition(ctx context.Context, id int64, to domain.ArticleStatus, publishAt *time.Time) (res domain.Article, err error) {
+	if !to.IsValid() {
+		return domain.Article{}, fmt.Errorf("%w: unknown status %q", domain.ErrBadParamInput, to)
+	}
+
+	res, err = a.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if !res.Status.CanTransitionTo(to) {
+		return domain.Article{}, fmt.Errorf("%w: %s to %s", domain.ErrInvalidTransition, res.Status, to)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	if publishAt != nil && to != domain.StatusScheduled {
+		return domain.Article{}, fmt.Errorf("%w: publish_at is only allowed when scheduling", domain.ErrBadParamInput)
+	}
+	switch to {
+	case domain.StatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return domain.Article{}, fmt.Errorf("%w: publish_at must be in the future", domain.ErrBadParamInput)
+		}
+		at := publishAt.Truncate(time.Second)
+		res.PublishAt = &at
+	case domain.StatusPublished:
+		res.PublishAt = &now
+	case domain.StatusDraft, domain.StatusInReview:
+		res.PublishAt = nil
+	}
+
+	from := res.Status
+	res.Status = to
+	res.UpdatedAt = now
+	err = a.articleRepo.UpdateStatus(ctx, &res, from)
 	if err != nil {
 		return domain.Article{}, err
 	}
 
-	res.Author = resAuthor
-	return
+	a.index(ctx, res)
+	return a.fillOne(ctx, res)
 }
 
-func (a *Service) Store(ctx context.Context, m *domain.Article) (err error) {
-	existedArticle, _ := a.GetByTitle(ctx, m.Title) // ignore if any error
-	if existedArticle != (domain.Article{}) {
-		return domain.ErrConflict
+// PublishDue will publish the scheduled articles whose publication time has come,
+// it returns how many of them were published
+func (a *Service) PublishDue(ctx context.Context, now time.Time) (total int64, err error) {
+	due, err := a.articleRepo.FetchDue(ctx, now)
+	if err != nil {
+		return 0, err
 	}
 
-	err = a.articleRepo.Store(ctx, m)
-	return
+	for _, ar := range due { //nolint
+		ar.Status = domain.StatusPublished
+		ar.UpdatedAt = now.Truncate(time.Second)
+		err = a.articleRepo.UpdateStatus(ctx, &ar, domain.StatusScheduled)
+		if errors.Is(err, domain.ErrPreconditionFailed) {
+			// it was unscheduled in between
+			continue
+		}
+		if err != nil {
+			return total, err
+		}
+
+		total++
+		a.index(ctx, ar)
+	}
+	return total, nil
 }
 
+// ensureAuthor rejects articles that reference an author who doesn't exist
+func (a *Service) ensureAuthor(ctx context.Context, authorID int64) error {
+	_, err := a.authorRepo.GetByID(ctx, authorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%w: author %d does not exist", domain.ErrBadParamInput, authorID)
+	}
+	return err
+}
+
+// Delete will move the article to the trash, it can be restored until it's purged
 func (a *Service) Delete(ctx context.Context, id int64) (err error) {
 	existedArticle, err := a.articleRepo.GetByID(ctx, id)
 	if err != nil {
 		return
 	}
-	if existedArticle == (domain.Article{}) {
+	if existedArticle.ID == 0 {
 		return domain.ErrNotFound
 	}
-	return a.articleRepo.Delete(ctx, id)
+	err = a.articleRepo.Delete(ctx, id, time.Now().Truncate(time.Second))
+	if err != nil {
+		return
+	}
+
+	a.unindex(ctx, id)
+	return
+}
+
+// Restore will take the article out of the trash
+func (a *Service) Restore(ctx context.Context, id int64) (res domain.Article, err error) {
+	err = a.articleRepo.Restore(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res, err = a.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	a.index(ctx, res)
+	return
+}
+
+// FetchTrash will fetch the trashed articles, the latest deleted first
+func (a *Service) FetchTrash(ctx context.Context) ([]domain.Article, error) {
+	res, err := a.articleRepo.FetchTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.fillDetails(ctx, res)
+}
+
+// PurgeTrash will permanently remove the articles trashed before the given time
+func (a *Service) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return a.articleRepo.Purge(ctx, before)
+}
+
+// FetchRevisions will fetch the revisions of the article, the latest first
+func (a *Service) FetchRevisions(ctx context.Context, articleID int64) ([]domain.Revision, error) {
+	_, err := a.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	return a.articleRepo.FetchRevisions(ctx, articleID)
+}
+
+// GetRevision will get the revision of the article by given id
+func (a *Service) GetRevision(ctx context.Context, articleID, revisionID int64) (res domain.Revision, err error) {
+	res, err = a.articleRepo.GetRevision(ctx, revisionID)
+	if err != nil {
+		return
+	}
+	if res.ArticleID != articleID {
+		return domain.Revision{}, domain.ErrNotFound
+	}
+	return
+}
+
+// DiffRevisions will compare two revisions of the article line by line
+func (a *Service) DiffRevisions(ctx context.Context, articleID, fromID, toID int64) (res domain.RevisionDiff, err error) {
+	from, err := a.GetRevision(ctx, articleID, fromID)
+	if err != nil {
+		return
+	}
+	to, err := a.GetRevision(ctx, articleID, toID)
+	if err != nil {
+		return
+	}
+
+	return domain.RevisionDiff{
+		From:    from.ID,
+		To:      to.ID,
+		Title:   diffLines(from.Title, to.Title),
+		Content: diffLines(from.Content, to.Content),
+	}, nil
+}
+
+// Rollback will restore the article as it was in the given revision. History is never rewritten,
+// the restored article is saved as a new revision
+func (a *Service) Rollback(ctx context.Context, articleID, revisionID int64) (res domain.Article, err error) {
+	revision, err := a.GetRevision(ctx, articleID, revisionID)
+	if err != nil {
+		return
+	}
+
+	res, err = a.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return
+	}
+	res.Title = revision.Title
+	res.Content = revision.Content
+	res.Author = revision.Author
+
+	err = a.Update(ctx, &res)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return a.fillOne(ctx, res)
+}
+
+// GetAuthor will get the author by given id
+func (a *Service) GetAuthor(ctx context.Context, id int64) (domain.Author, error) {
+	return a.authorRepo.GetByID(ctx, id)
+}
+
+// GetCategory will get the category by given id
+func (a *Service) GetCategory(ctx context.Context, id int64) (domain.Category, error) {
+	return a.categoryRepo.GetByID(ctx, id)
+}
+
+// FetchCategories will fetch every available category
+func (a *Service) FetchCategories(ctx context.Context) ([]domain.Category, error) {
+	return a.categoryRepo.Fetch(ctx)
+}
+
+// AddCategory will assign the category to the article
+func (a *Service) AddCategory(ctx context.Context, articleID, categoryID int64) (err error) {
+	_, err = a.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return
+	}
+
+	_, err = a.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return
+	}
+
+	return a.categoryRepo.AddArticle(ctx, articleID, categoryID)
+}
+
+// RemoveCategory will unassign the category from the article
+func (a *Service) RemoveCategory(ctx context.Context, articleID, categoryID int64) (err error) {
+	return a.categoryRepo.RemoveArticle(ctx, articleID, categoryID)
+}
+
+// SuggestTags will fetch the num tags starting with the prefix, the most used first
+func (a *Service) SuggestTags(ctx context.Context, prefix string, num int64) ([]domain.Tag, error) {
+	if a.tagRepo == nil {
+		return nil, errTagsDisabled
+	}
+	return a.tagRepo.Suggest(ctx, foldTag(prefix), num)
+}
+
+// SetTags will replace the tags of the article, it returns them as they're stored
+func (a *Service) SetTags(ctx context.Context, articleID int64, tags []string) (res []string, err error) {
+	if a.tagRepo == nil {
+		return nil, errTagsDisabled
+	}
+
+	res, err = normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > maxTags {
+		return nil, fmt.Errorf("%w: an article can't have more than %d tags", domain.ErrBadParamInput, maxTags)
+	}
+
+	_, err = a.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.tagRepo.SetArticleTags(ctx, articleID, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RenameTag will rename the tag on every article carrying it. Renaming it to the name of
+// another tag merges it into that tag, which is returned instead
+func (a *Service) RenameTag(ctx context.Context, id int64, name string) (res domain.Tag, err error) {
+	if a.tagRepo == nil {
+		return domain.Tag{}, errTagsDisabled
+	}
+
+	name, err = normalizeTag(name)
+	if err != nil {
+		return
+	}
+
+	res, err = a.tagRepo.GetByID(ctx, id)
+	if err != nil || res.Name == name {
+		return
+	}
+
+	existing, err := a.tagRepo.GetByName(ctx, name)
+	switch {
+	case err == nil:
+		return a.MergeTags(ctx, id, existing.ID)
+	case !errors.Is(err, domain.ErrNotFound):
+		return domain.Tag{}, err
+	}
+
+	err = a.tagRepo.Rename(ctx, id, name)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	res.Name = name
+	return res, nil
+}
+
+// MergeTags will move the articles of the fromID tag over to the intoID tag and remove the fromID tag
+func (a *Service) MergeTags(ctx context.Context, fromID, intoID int64) (res domain.Tag, err error) {
+	if a.tagRepo == nil {
+		return domain.Tag{}, errTagsDisabled
+	}
+	if fromID == intoID {
+		return domain.Tag{}, fmt.Errorf("%w: a tag can't be merged into itself", domain.ErrBadParamInput)
+	}
+
+	_, err = a.tagRepo.GetByID(ctx, intoID)
+	if err != nil {
+		return
+	}
+	err = a.tagRepo.Merge(ctx, fromID, intoID)
+	if err != nil {
+		return
+	}
+	return a.tagRepo.GetByID(ctx, intoID)
 }
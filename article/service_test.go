@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,52 +13,113 @@ import (
 	"github.com/bxcodec/go-clean-arch/article"
 	"github.com/bxcodec/go-clean-arch/article/mocks"
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+	"github.com/bxcodec/go-clean-arch/internal/repository/memory"
 )
 
 func TestFetchArticle(t *testing.T) {
 	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
 	mockArticle := domain.Article{
+		ID:      1,
 		Title:   "Hello",
 		Content: "Content",
+		Author:  domain.Author{ID: 1},
 	}
 
 	mockListArtilce := make([]domain.Article, 0)
 	mockListArtilce = append(mockListArtilce, mockArticle)
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(mockListArtilce, "next-cursor", nil).Once()
+		mockArticleRepo.On("Fetch", mock.Anything,
+			mock.AnythingOfType("domain.ArticleQuery")).Return(mockListArtilce, domain.Page{Next: "next-cursor"}, nil).Once()
 		mockAuthor := domain.Author{
 			ID:   1,
 			Name: "Iman Tumorang",
 		}
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{mockAuthor}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(map[int64][]domain.Category{}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, page, err := u.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursor, Num: num})
 		cursorExpected := "next-cursor"
-		assert.Equal(t, cursorExpected, nextCursor)
-		assert.NotEmpty(t, nextCursor)
+		assert.Equal(t, cursorExpected, page.Next)
+		assert.NotEmpty(t, page.Next)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListArtilce))
+		assert.Equal(t, mockAuthor, list[0].Author)
+
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+
+	t.Run("author-missing-fails-page", func(t *testing.T) {
+		mockArticleRepo.On("Fetch", mock.Anything,
+			mock.AnythingOfType("domain.ArticleQuery")).Return([]domain.Article{mockArticle}, domain.Page{Next: "next-cursor"}, nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		list, page, err := u.Fetch(context.TODO(), domain.ArticleQuery{Cursor: "12", Num: 1})
+
+		assert.Error(t, err)
+		assert.Empty(t, page)
+		assert.Len(t, list, 0)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
 
+	t.Run("author-error-partial-page", func(t *testing.T) {
+		mockArticleRepo.On("Fetch", mock.Anything,
+			mock.AnythingOfType("domain.ArticleQuery")).Return([]domain.Article{mockArticle}, domain.Page{Next: "next-cursor"}, nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return(nil, errors.New("Unexpexted Error")).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{1}).Return(map[int64][]domain.Category{}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithAuthorFailureMode(article.PartialPage))
+
+		list, page, err := u.Fetch(context.TODO(), domain.ArticleQuery{Cursor: "12", Num: 1})
+
+		assert.ErrorIs(t, err, domain.ErrPartialResult)
+		assert.Equal(t, "next-cursor", page.Next)
+		assert.Len(t, list, 1)
+		assert.Equal(t, domain.Author{ID: 1}, list[0].Author)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("tagged", func(t *testing.T) {
+		mockArticleRepo.On("Fetch", mock.Anything, mock.MatchedBy(func(q domain.ArticleQuery) bool {
+			return assert.ObjectsAreEqual([]string{"clean arch", "go"}, q.Tags)
+		})).Return([]domain.Article{mockArticle}, domain.Page{}, nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1}}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{1}).Return(map[int64][]domain.Category{}, nil).Once()
+		mockTagRepo := new(mocks.TagRepository)
+		mockTagRepo.On("GetByArticleIDs", mock.Anything, []int64{1}).Return(map[int64][]string{1: {"clean arch", "go"}}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithTags(mockTagRepo))
+
+		list, _, err := u.Fetch(context.TODO(), domain.ArticleQuery{Num: 1, Tags: []string{"Go", " Clean  Arch", "go"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"clean arch", "go"}, list[0].Tags)
+		mockArticleRepo.AssertExpectations(t)
+		mockTagRepo.AssertExpectations(t)
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(nil, "", errors.New("Unexpexted Error")).Once()
+		mockArticleRepo.On("Fetch", mock.Anything,
+			mock.AnythingOfType("domain.ArticleQuery")).Return(nil, domain.Page{}, errors.New("Unexpexted Error")).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, page, err := u.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursor, Num: num})
 
-		assert.Empty(t, nextCursor)
+		assert.Empty(t, page)
 		assert.Error(t, err)
 		assert.Len(t, list, 0)
 		mockArticleRepo.AssertExpectations(t)
@@ -66,6 +129,7 @@ func TestFetchArticle(t *testing.T) {
 
 func TestGetByID(t *testing.T) {
 	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
 	mockArticle := domain.Article{
 		Title:   "Hello",
 		Content: "Content",
@@ -79,7 +143,8 @@ func TestGetByID(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
 		mockAuthorrepo := new(mocks.AuthorRepository)
 		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(map[int64][]domain.Category{}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		a, err := u.GetByID(context.TODO(), mockArticle.ID)
 
@@ -93,7 +158,7 @@ func TestGetByID(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Article{}, errors.New("Unexpected")).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		a, err := u.GetByID(context.TODO(), mockArticle.ID)
 
@@ -107,6 +172,7 @@ func TestGetByID(t *testing.T) {
 
 func TestStore(t *testing.T) {
 	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
 	mockArticle := domain.Article{
 		Title:   "Hello",
 		Content: "Content",
@@ -115,52 +181,150 @@ func TestStore(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tempMockArticle := mockArticle
 		tempMockArticle.ID = 0
-		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Article{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(true, nil).Once()
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello-2", int64(0)).Return(false, nil).Once()
 		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(nil).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		err := u.Store(context.TODO(), &tempMockArticle)
 
 		assert.NoError(t, err)
 		assert.Equal(t, mockArticle.Title, tempMockArticle.Title)
+		assert.Equal(t, domain.StatusDraft, tempMockArticle.Status)
+		assert.Equal(t, "hello-2", tempMockArticle.Slug)
+		assert.False(t, tempMockArticle.CreatedAt.IsZero())
+		assert.Equal(t, 1, tempMockArticle.WordCount)
+		assert.Equal(t, "Content", tempMockArticle.Excerpt)
 		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+	t.Run("author-is-not-exist", func(t *testing.T) {
+		tempMockArticle := mockArticle
+
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(0)).Return(domain.Author{}, domain.ErrNotFound).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		err := u.Store(context.TODO(), &tempMockArticle)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
 	})
 	t.Run("existing-title", func(t *testing.T) {
-		existingArticle := mockArticle
-		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(existingArticle, nil).Once()
-		mockAuthor := domain.Author{
-			ID:   1,
-			Name: "Iman Tumorang",
-		}
+		tempMockArticle := mockArticle
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(false, nil).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(domain.ErrConflict).Once()
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil)
 
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
-		err := u.Store(context.TODO(), &mockArticle)
+		err := u.Store(context.TODO(), &tempMockArticle)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrConflict)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
 	})
+	t.Run("existing-title-suffixed", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(true, nil).Once()
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello-2", int64(0)).Return(false, nil).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Title == "Hello"
+		})).Return(domain.ErrTitleTaken).Once()
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello-2", int64(0)).Return(false, nil).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Title == "Hello (2)"
+		})).Return(nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil)
+
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithTitlePolicy(article.SuffixDuplicateTitle))
+
+		err := u.Store(context.TODO(), &tempMockArticle)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello (2)", tempMockArticle.Title)
+		assert.Equal(t, "hello-2", tempMockArticle.Slug)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("existing-long-title-suffixed", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Title = "Über die Klassifikation der Körper nach Größe"
+		mockArticleRepo.On("SlugExists", mock.Anything, mock.Anything, int64(0)).Return(false, nil).Twice()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Title == "Über die Klassifikation der Körper nach Größe"
+		})).Return(domain.ErrTitleTaken).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Title == "Über die Klassifikation der Körper nach G (2)"
+		})).Return(nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil)
+
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithTitlePolicy(article.SuffixDuplicateTitle))
+
+		err := u.Store(context.TODO(), &tempMockArticle)
+
+		assert.NoError(t, err)
+		// cut short to the 45 characters of the title column
+		assert.Equal(t, 45, utf8.RuneCountInString(tempMockArticle.Title))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("existing-slug-not-suffixed", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(false, nil).Once()
+		// the slug was taken in between, it's not the title's fault
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(domain.ErrConflict).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil)
+
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithTitlePolicy(article.SuffixDuplicateTitle))
+
+		err := u.Store(context.TODO(), &tempMockArticle)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.Equal(t, "Hello", tempMockArticle.Title)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("title-scoped-per-author", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Author = domain.Author{ID: 3}
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(false, nil).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.TitleScope == 3
+		})).Return(nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(3)).Return(domain.Author{ID: 3}, nil)
+
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithTitlePolicy(article.DuplicateTitlePerAuthor))
+
+		err := u.Store(context.TODO(), &tempMockArticle)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
 }
 
 func TestDelete(t *testing.T) {
 	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
 	mockArticle := domain.Article{
 		Title:   "Hello",
 		Content: "Content",
+		ID:      1,
 	}
 
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
 
-		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
@@ -172,7 +336,7 @@ func TestDelete(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Article{}, nil).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
@@ -184,7 +348,7 @@ func TestDelete(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Article{}, errors.New("Unexpected Error")).Once()
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
@@ -196,6 +360,7 @@ func TestDelete(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
 	mockArticle := domain.Article{
 		Title:   "Hello",
 		Content: "Content",
@@ -203,13 +368,626 @@ func TestUpdate(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(23)).Return(false, nil).Once()
 		mockArticleRepo.On("Update", mock.Anything, &mockArticle).Once().Return(nil)
 
 		mockAuthorrepo := new(mocks.AuthorRepository)
-		u := article.NewService(mockArticleRepo, mockAuthorrepo)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
 
 		err := u.Update(context.TODO(), &mockArticle)
 		assert.NoError(t, err)
 		mockArticleRepo.AssertExpectations(t)
 	})
+	t.Run("modified-concurrently", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Version = 2
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(23)).Return(false, nil).Once()
+		mockArticleRepo.On("Update", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Version == 2
+		})).Once().Return(domain.ErrPreconditionFailed)
+
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		err := u.Update(context.TODO(), &tempMockArticle)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestAddCategory(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockArticle := domain.Article{
+		ID:      12,
+		Title:   "Hello",
+		Content: "Content",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Category{ID: 2}, nil).Once()
+		mockCategoryRepo.On("AddArticle", mock.Anything, int64(12), int64(2)).Return(nil).Once()
+
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		err := u.AddCategory(context.TODO(), 12, 2)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("category-is-not-exist", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Category{}, domain.ErrNotFound).Once()
+
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		err := u.AddCategory(context.TODO(), 12, 9)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestFetchByCategory(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockArticle := domain.Article{
+		ID:      12,
+		Title:   "Hello",
+		Content: "Content",
+		Author:  domain.Author{ID: 1},
+	}
+	mockCategory := domain.Category{ID: 2, Name: "Golang"}
+
+	mockCategoryRepo.On("GetByID", mock.Anything, int64(2)).Return(mockCategory, nil).Once()
+	mockArticleRepo.On("FetchByCategory", mock.Anything, int64(2), domain.ArticleQuery{Num: 10}).
+		Return([]domain.Article{mockArticle}, domain.Page{Next: "next-cursor"}, nil).Once()
+	mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{12}).
+		Return(map[int64][]domain.Category{12: {mockCategory}}, nil).Once()
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1, Name: "Iman Tumorang"}}, nil)
+
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+	list, page, err := u.FetchByCategory(context.TODO(), 2, domain.ArticleQuery{Num: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "next-cursor", page.Next)
+	assert.Len(t, list, 1)
+	assert.Equal(t, []domain.Category{mockCategory}, list[0].Categories)
+	assert.Equal(t, "Iman Tumorang", list[0].Author.Name)
+	mockArticleRepo.AssertExpectations(t)
+	mockCategoryRepo.AssertExpectations(t)
+	mockAuthorrepo.AssertExpectations(t)
+}
+
+func TestSearch(t *testing.T) {
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	searchRepo := memory.NewSearchRepository(repository.NewCursorCodec([]byte("secret")),
+		domain.Article{ID: 1, Title: "Makan Ayam", Content: "ayam goreng", Author: domain.Author{ID: 1}, Status: domain.StatusPublished},
+		domain.Article{ID: 2, Title: "Makan Ikan", Content: "ikan bakar", Author: domain.Author{ID: 1}, Status: domain.StatusPublished},
+	)
+	u := article.NewService(new(mocks.ArticleRepository), mockAuthorrepo, mockCategoryRepo, searchRepo)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1, Name: "Iman Tumorang"}}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{2}).Return(map[int64][]domain.Category{}, nil).Once()
+
+		hits, page, err := u.Search(context.TODO(), domain.SearchQuery{Terms: "ikan", Num: 10})
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Next)
+		assert.Len(t, hits, 1)
+		assert.Equal(t, "Iman Tumorang", hits[0].Article.Author.Name)
+		assert.Equal(t, "<mark>ikan</mark> bakar", hits[0].Snippet)
+		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty-search", func(t *testing.T) {
+		hits, _, err := u.Search(context.TODO(), domain.SearchQuery{Terms: "  ", Num: 10})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		assert.Len(t, hits, 0)
+	})
+}
+
+func TestSemanticSearch(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockSemanticRepo := new(mocks.SemanticRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithSemanticIndex(mockSemanticRepo))
+
+	t.Run("success", func(t *testing.T) {
+		mockSemanticRepo.On("Search", mock.Anything, "ayam goreng", int64(10)).
+			Return([]domain.SearchHit{{Article: domain.Article{ID: 3}, Score: 0.8}, {Article: domain.Article{ID: 7}, Score: 0.5}}, nil).Once()
+		// article 7 has been deleted since it was indexed
+		mockArticleRepo.On("GetByIDs", mock.Anything, []int64{3, 7}).
+			Return([]domain.Article{{ID: 3, Title: "Makan Ayam", Author: domain.Author{ID: 1}, Status: domain.StatusPublished}}, nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1, Name: "Iman Tumorang"}}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{3}).Return(map[int64][]domain.Category{}, nil).Once()
+
+		hits, err := u.SemanticSearch(context.TODO(), "ayam goreng", 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, hits, 1)
+		assert.Equal(t, "Makan Ayam", hits[0].Article.Title)
+		assert.Equal(t, "Iman Tumorang", hits[0].Article.Author.Name)
+		assert.Equal(t, 0.8, hits[0].Score)
+		mockSemanticRepo.AssertExpectations(t)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+
+	t.Run("not-enabled", func(t *testing.T) {
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		_, err := u.SemanticSearch(context.TODO(), "ayam goreng", 10)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestDiffRevisions(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockArticleRepo.On("GetRevision", mock.Anything, int64(2)).
+		Return(domain.Revision{ID: 2, ArticleID: 12, Title: "Hello", Content: "one\ntwo\nthree"}, nil)
+	mockArticleRepo.On("GetRevision", mock.Anything, int64(5)).
+		Return(domain.Revision{ID: 5, ArticleID: 12, Title: "Hello", Content: "one\nthree\nfour"}, nil)
+	mockArticleRepo.On("GetRevision", mock.Anything, int64(7)).
+		Return(domain.Revision{ID: 7, ArticleID: 13}, nil)
+	u := article.NewService(mockArticleRepo, new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil)
+
+	t.Run("success", func(t *testing.T) {
+		diff, err := u.DiffRevisions(context.TODO(), 12, 2, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.DiffLine{{Op: domain.DiffEqual, Text: "Hello"}}, diff.Title)
+		assert.Equal(t, []domain.DiffLine{
+			{Op: domain.DiffEqual, Text: "one"},
+			{Op: domain.DiffDelete, Text: "two"},
+			{Op: domain.DiffEqual, Text: "three"},
+			{Op: domain.DiffInsert, Text: "four"},
+		}, diff.Content)
+	})
+
+	t.Run("revision-of-another-article", func(t *testing.T) {
+		_, err := u.DiffRevisions(context.TODO(), 12, 2, 7)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestRollback(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	current := domain.Article{ID: 12, Title: "Hello", Content: "new content", Author: domain.Author{ID: 1}, UpdatedAt: updatedAt,
+		Version: 4}
+
+	mockArticleRepo.On("GetRevision", mock.Anything, int64(2)).
+		Return(domain.Revision{ID: 2, ArticleID: 12, Title: "Hello", Content: "old content", Author: domain.Author{ID: 1}}, nil).Once()
+	mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(current, nil).Once()
+	mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(12)).Return(false, nil).Once()
+	mockArticleRepo.On("Update", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.Content == "old content" && ar.Version == 4
+	})).Return(nil).Once()
+	mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1, Name: "Iman Tumorang"}, nil)
+	mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{12}).Return(map[int64][]domain.Category{}, nil).Once()
+
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+	res, err := u.Rollback(context.TODO(), 12, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "old content", res.Content)
+	assert.Equal(t, "Iman Tumorang", res.Author.Name)
+	assert.True(t, res.UpdatedAt.After(updatedAt))
+	mockArticleRepo.AssertExpectations(t)
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestRestore(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	mockSemanticRepo := new(mocks.SemanticRepository)
+	mockArticle := domain.Article{ID: 12, Title: "Hello", Content: "Content", Author: domain.Author{ID: 1}, Status: domain.StatusPublished}
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithSemanticIndex(mockSemanticRepo))
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("Restore", mock.Anything, int64(12)).Return(nil).Once()
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1, Name: "Iman Tumorang"}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{12}).Return(map[int64][]domain.Category{}, nil).Once()
+		mockSemanticRepo.On("Index", mock.Anything, mock.MatchedBy(func(ar domain.Article) bool { return ar.ID == 12 })).Return(nil).Once()
+
+		res, err := u.Restore(context.TODO(), 12)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Iman Tumorang", res.Author.Name)
+		mockArticleRepo.AssertExpectations(t)
+		mockSemanticRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-in-trash", func(t *testing.T) {
+		mockArticleRepo.On("Restore", mock.Anything, int64(13)).Return(domain.ErrNotFound).Once()
+
+		_, err := u.Restore(context.TODO(), 13)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockArticleRepo.AssertExpectations(t)
+		mockSemanticRepo.AssertExpectations(t)
+	})
+}
+
+func TestTransition(t *testing.T) {
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1, Name: "Iman Tumorang"}, nil)
+	mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{12}).Return(map[int64][]domain.Category{}, nil)
+
+	t.Run("publish", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).
+			Return(domain.Article{ID: 12, Author: domain.Author{ID: 1}, Status: domain.StatusInReview}, nil).Once()
+		mockArticleRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.Status == domain.StatusPublished && ar.PublishAt != nil
+		}), domain.StatusInReview).Return(nil).Once()
+		mockSemanticRepo := new(mocks.SemanticRepository)
+		mockSemanticRepo.On("Index", mock.Anything, mock.AnythingOfType("domain.Article")).Return(errors.New("unavailable")).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil, article.WithSemanticIndex(mockSemanticRepo))
+
+		a, err := u.Transition(context.TODO(), 12, domain.StatusPublished, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusPublished, a.Status)
+		assert.Equal(t, "Iman Tumorang", a.Author.Name)
+		mockArticleRepo.AssertExpectations(t)
+		mockSemanticRepo.AssertExpectations(t)
+	})
+
+	t.Run("schedule-in-the-past", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).
+			Return(domain.Article{ID: 12, Author: domain.Author{ID: 1}, Status: domain.StatusInReview}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+		publishAt := time.Now().Add(-time.Hour)
+
+		_, err := u.Transition(context.TODO(), 12, domain.StatusScheduled, &publishAt)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockArticleRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("skipping-review", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).
+			Return(domain.Article{ID: 12, Author: domain.Author{ID: 1}, Status: domain.StatusDraft}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+		_, err := u.Transition(context.TODO(), 12, domain.StatusPublished, nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		mockArticleRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPublishDue(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	publishAt := now.Add(-time.Minute)
+	due := []domain.Article{
+		{ID: 12, Status: domain.StatusScheduled, PublishAt: &publishAt},
+		{ID: 13, Status: domain.StatusScheduled, PublishAt: &publishAt},
+	}
+
+	mockArticleRepo.On("FetchDue", mock.Anything, now).Return(due, nil).Once()
+	mockArticleRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.ID == 12 && ar.Status == domain.StatusPublished && ar.PublishAt.Equal(publishAt)
+	}), domain.StatusScheduled).Return(nil).Once()
+	// article 13 was unscheduled in between
+	mockArticleRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.ID == 13
+	}), domain.StatusScheduled).Return(domain.ErrPreconditionFailed).Once()
+	u := article.NewService(mockArticleRepo, new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil)
+
+	total, err := u.PublishDue(context.TODO(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	mockArticleRepo.AssertExpectations(t)
+}
+
+func TestGetBySlug(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	mockArticle := domain.Article{ID: 12, Title: "Hello", Slug: "hello", Author: domain.Author{ID: 1}}
+
+	mockArticleRepo.On("GetBySlug", mock.Anything, "hello-world").Return(mockArticle, nil).Once()
+	mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1, Name: "Iman Tumorang"}, nil).Once()
+	mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{12}).Return(map[int64][]domain.Category{}, nil).Once()
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil)
+
+	a, err := u.GetBySlug(context.TODO(), "hello-world")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", a.Slug)
+	assert.Equal(t, "Iman Tumorang", a.Author.Name)
+	mockArticleRepo.AssertExpectations(t)
+}
+
+func TestSetTags(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockTagRepo := new(mocks.TagRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(domain.Article{ID: 12}, nil).Once()
+		mockTagRepo.On("SetArticleTags", mock.Anything, int64(12), []string{"go", "mysql"}).Return(nil).Once()
+		u := article.NewService(mockArticleRepo, nil, nil, nil, article.WithTags(mockTagRepo))
+
+		tags, err := u.SetTags(context.TODO(), 12, []string{"MySQL", "go", "Go"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go", "mysql"}, tags)
+		mockArticleRepo.AssertExpectations(t)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("empty-tag", func(t *testing.T) {
+		u := article.NewService(mockArticleRepo, nil, nil, nil, article.WithTags(mockTagRepo))
+
+		_, err := u.SetTags(context.TODO(), 12, []string{"go", "  "})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("tags-disabled", func(t *testing.T) {
+		u := article.NewService(mockArticleRepo, nil, nil, nil)
+
+		_, err := u.SetTags(context.TODO(), 12, []string{"go"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestRenameTag(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, int64(3)).Return(domain.Tag{ID: 3, Name: "golang", Usage: 4}, nil).Once()
+		mockTagRepo.On("GetByName", mock.Anything, "go").Return(domain.Tag{}, domain.ErrNotFound).Once()
+		mockTagRepo.On("Rename", mock.Anything, int64(3), "go").Return(nil).Once()
+		u := article.NewService(nil, nil, nil, nil, article.WithTags(mockTagRepo))
+
+		tag, err := u.RenameTag(context.TODO(), 3, " Go ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Tag{ID: 3, Name: "go", Usage: 4}, tag)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("merged-into-existing-tag", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, int64(3)).Return(domain.Tag{ID: 3, Name: "golang", Usage: 4}, nil).Once()
+		mockTagRepo.On("GetByName", mock.Anything, "go").Return(domain.Tag{ID: 1, Name: "go", Usage: 10}, nil).Once()
+		mockTagRepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Tag{ID: 1, Name: "go", Usage: 10}, nil).Once()
+		mockTagRepo.On("Merge", mock.Anything, int64(3), int64(1)).Return(nil).Once()
+		mockTagRepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Tag{ID: 1, Name: "go", Usage: 13}, nil).Once()
+		u := article.NewService(nil, nil, nil, nil, article.WithTags(mockTagRepo))
+
+		tag, err := u.RenameTag(context.TODO(), 3, "go")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Tag{ID: 1, Name: "go", Usage: 13}, tag)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestMergeTags(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	u := article.NewService(nil, nil, nil, nil, article.WithTags(mockTagRepo))
+
+	_, err := u.MergeTags(context.TODO(), 3, 3)
+
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestImport(t *testing.T) {
+	publishedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("keeps-the-status", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(false, nil).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil)
+
+		ar := domain.Article{ID: 42, Title: "Hello", Content: "Content", Author: domain.Author{ID: 1},
+			Status: domain.StatusPublished, PublishAt: &publishedAt, CreatedAt: publishedAt}
+		err := u.Import(context.TODO(), &ar, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), ar.ID)
+		assert.Equal(t, domain.StatusPublished, ar.Status)
+		assert.Equal(t, publishedAt, *ar.PublishAt)
+		assert.Equal(t, publishedAt, ar.CreatedAt)
+		assert.False(t, ar.UpdatedAt.IsZero())
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+	t.Run("published-without-publish-at", func(t *testing.T) {
+		for _, createdAt := range []time.Time{publishedAt, {}} {
+			mockArticleRepo := new(mocks.ArticleRepository)
+			mockArticleRepo.On("SlugExists", mock.Anything, "hello", int64(0)).Return(false, nil).Once()
+			mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(nil).Once()
+			mockAuthorrepo := new(mocks.AuthorRepository)
+			mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1}, nil).Once()
+			u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil)
+
+			ar := domain.Article{Title: "Hello", Content: "Content", Author: domain.Author{ID: 1},
+				Status: domain.StatusPublished, CreatedAt: createdAt}
+			err := u.Import(context.TODO(), &ar, false)
+
+			// it went public when it was created, the feeds sort it among the others
+			assert.NoError(t, err)
+			if assert.NotNil(t, ar.PublishAt) {
+				assert.Equal(t, ar.CreatedAt, *ar.PublishAt)
+			}
+			mockArticleRepo.AssertExpectations(t)
+		}
+	})
+	t.Run("scheduled-without-publish-at", func(t *testing.T) {
+		u := article.NewService(new(mocks.ArticleRepository), new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil)
+
+		ar := domain.Article{Title: "Hello", Content: "Content", Status: domain.StatusScheduled}
+		err := u.Import(context.TODO(), &ar, false)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+	t.Run("dry-run-taken-title", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("TitleExists", mock.Anything, int64(0), "Hello").Return(true, nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil)
+
+		ar := domain.Article{Title: "Hello", Content: "Content", Author: domain.Author{ID: 1}}
+		err := u.Import(context.TODO(), &ar, true)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockArticleRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+	t.Run("dry-run-title-per-author", func(t *testing.T) {
+		for _, taken := range []bool{true, false} {
+			mockArticleRepo := new(mocks.ArticleRepository)
+			// the title is looked up among the author's own articles only
+			mockArticleRepo.On("TitleExists", mock.Anything, int64(1), "Hello").Return(taken, nil).Once()
+			mockAuthorrepo := new(mocks.AuthorRepository)
+			mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1}, nil).Once()
+			u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil,
+				article.WithTitlePolicy(article.DuplicateTitlePerAuthor))
+
+			ar := domain.Article{Title: "Hello", Content: "Content", Author: domain.Author{ID: 1}}
+			err := u.Import(context.TODO(), &ar, true)
+
+			if taken {
+				assert.ErrorIs(t, err, domain.ErrConflict)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockArticleRepo.AssertExpectations(t)
+		}
+	})
+	t.Run("dry-run-suffixed-title", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Author{ID: 1}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil,
+			article.WithTitlePolicy(article.SuffixDuplicateTitle))
+
+		ar := domain.Article{Title: "Hello", Content: "Content", Author: domain.Author{ID: 1}}
+		err := u.Import(context.TODO(), &ar, true)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+}
+
+func TestExport(t *testing.T) {
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockArticleRepo.On("Fetch", mock.Anything, domain.ArticleQuery{Num: 100}).
+		Return([]domain.Article{{ID: 1, Author: domain.Author{ID: 1}}}, domain.Page{Next: "next-cursor"}, nil).Once()
+	mockArticleRepo.On("Fetch", mock.Anything, domain.ArticleQuery{Cursor: "next-cursor", Num: 100}).
+		Return([]domain.Article{{ID: 2, Author: domain.Author{ID: 1}}}, domain.Page{Prev: "prev-cursor"}, nil).Once()
+	mockAuthorrepo := new(mocks.AuthorRepository)
+	mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1, Name: "Iman Tumorang"}}, nil).Twice()
+	u := article.NewService(mockArticleRepo, mockAuthorrepo, new(mocks.CategoryRepository), nil)
+
+	var got []domain.Article
+	err := u.Export(context.TODO(), func(ar domain.Article) error {
+		got = append(got, ar)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "Iman Tumorang", got[1].Author.Name)
+	mockArticleRepo.AssertExpectations(t)
+	mockAuthorrepo.AssertExpectations(t)
+}
+
+func TestRecordView(t *testing.T) {
+	mockCounter := new(mocks.ViewCounter)
+	mockCounter.On("Record", int64(1), "192.0.2.1 firefox").Once()
+	u := article.NewService(new(mocks.ArticleRepository), new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil,
+		article.WithViews(mockCounter, new(mocks.ViewRepository)))
+
+	u.RecordView(context.TODO(), domain.Article{ID: 1, Status: domain.StatusPublished}, "192.0.2.1 firefox")
+	// a draft previewed by its author isn't read
+	u.RecordView(context.TODO(), domain.Article{ID: 2, Status: domain.StatusDraft}, "192.0.2.1 firefox")
+
+	mockCounter.AssertExpectations(t)
+}
+
+func TestTrending(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockViewRepo := new(mocks.ViewRepository)
+		mockViewRepo.On("Trending", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"),
+			6*time.Hour, int64(10)).
+			Return([]domain.TrendingArticle{
+				{Article: domain.Article{ID: 7}, Views: 40, Score: 31.5},
+				{Article: domain.Article{ID: 3}, Views: 50, Score: 20},
+				{Article: domain.Article{ID: 1}, Views: 90, Score: 12.25},
+			}, nil).Once()
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByIDs", mock.Anything, []int64{7, 3, 1}).Return([]domain.Article{
+			{ID: 1, Title: "Hello", Status: domain.StatusPublished, Author: domain.Author{ID: 1}},
+			{ID: 3, Title: "Unpublished", Status: domain.StatusDraft, Author: domain.Author{ID: 1}},
+			{ID: 7, Title: "World", Status: domain.StatusPublished, Author: domain.Author{ID: 1}},
+		}, nil).Once()
+		mockAuthorrepo := new(mocks.AuthorRepository)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return([]domain.Author{{ID: 1, Name: "Iman Tumorang"}}, nil).Once()
+		mockCategoryRepo := new(mocks.CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{7, 1}).Return(map[int64][]domain.Category{}, nil).Once()
+		u := article.NewService(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, nil,
+			article.WithViews(new(mocks.ViewCounter), mockViewRepo))
+
+		list, err := u.Trending(context.TODO(), 24*time.Hour, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, "World", list[0].Article.Title)
+		assert.Equal(t, int64(40), list[0].Views)
+		assert.Equal(t, "Iman Tumorang", list[1].Article.Author.Name)
+		mockViewRepo.AssertExpectations(t)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+	t.Run("bad-window", func(t *testing.T) {
+		u := article.NewService(new(mocks.ArticleRepository), new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil,
+			article.WithViews(new(mocks.ViewCounter), new(mocks.ViewRepository)))
+
+		for _, window := range []time.Duration{0, -time.Hour, 10 * time.Minute, 90 * 24 * time.Hour} {
+			_, err := u.Trending(context.TODO(), window, 10)
+			assert.ErrorIs(t, err, domain.ErrBadParamInput, window)
+		}
+	})
+	t.Run("disabled", func(t *testing.T) {
+		u := article.NewService(new(mocks.ArticleRepository), new(mocks.AuthorRepository), new(mocks.CategoryRepository), nil)
+
+		_, err := u.Trending(context.TODO(), time.Hour, 10)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
 }
@@ -0,0 +1,106 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// maxSlugLength keeps the slugs short enough for URLs, the uniqueness suffix comes on top of it
+	maxSlugLength = 80
+	// maxSlugAttempts bounds the numbered slugs tried before giving up
+	maxSlugAttempts = 100
+)
+
+// latinFolds spells out the Latin letters that don't decompose into a base letter and a mark
+var latinFolds = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'ø': "o", 'Ø': "o", 'œ': "oe", 'Œ': "oe",
+	'đ': "d", 'Đ': "d", 'ł': "l", 'Ł': "l", 'þ': "th", 'Þ': "th", 'ð': "d", 'Ð': "d",
+}
+
+// thaiLetters romanizes the Thai script letter by letter, after the consonants'
+// initial sound in the Royal Thai General System
+var thaiLetters = map[rune]string{
+	'ก': "k", 'ข': "kh", 'ฃ': "kh", 'ค': "kh", 'ฅ': "kh", 'ฆ': "kh", 'ง': "ng",
+	'จ': "ch", 'ฉ': "ch", 'ช': "ch", 'ซ': "s", 'ฌ': "ch", 'ญ': "y",
+	'ฎ': "d", 'ฏ': "t", 'ฐ': "th", 'ฑ': "th", 'ฒ': "th", 'ณ': "n",
+	'ด': "d", 'ต': "t", 'ถ': "th", 'ท': "th", 'ธ': "th", 'น': "n",
+	'บ': "b", 'ป': "p", 'ผ': "ph", 'ฝ': "f", 'พ': "ph", 'ฟ': "f", 'ภ': "ph", 'ม': "m",
+	'ย': "y", 'ร': "r", 'ฤ': "rue", 'ล': "l", 'ฦ': "lue", 'ว': "w",
+	'ศ': "s", 'ษ': "s", 'ส': "s", 'ห': "h", 'ฬ': "l", 'อ': "o", 'ฮ': "h",
+	'ะ': "a", 'ั': "a", 'า': "a", 'ำ': "am", 'ิ': "i", 'ี': "i", 'ึ': "ue", 'ื': "ue", 'ุ': "u", 'ู': "u",
+	'เ': "e", 'แ': "ae", 'โ': "o", 'ใ': "ai", 'ไ': "ai",
+}
+
+// isThaiLeadingVowel reports whether the vowel is written before the consonant it's pronounced after
+func isThaiLeadingVowel(r rune) bool {
+	return r >= 'เ' && r <= 'ไ'
+}
+
+// isThaiConsonant reports whether r is one of the Thai consonants
+func isThaiConsonant(r rune) bool {
+	return r >= 'ก' && r <= 'ฮ'
+}
+
+// slugify turns the title into a lowercase, URL-safe slug made of ASCII letters, digits and hyphens.
+// Accents are dropped and Thai is romanized, the other scripts are left out
+func slugify(title string) string {
+	runes := []rune(norm.NFD.String(title))
+
+	var b strings.Builder
+	hyphen := false
+	write := func(s string) {
+		if hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		hyphen = false
+		b.WriteString(s)
+	}
+
+	// lastConsonant is where the latest Thai consonant starts in b, the thanthakhat silences it
+	lastConsonant := -1
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(unicode.ToLower(r)))
+		case latinFolds[r] != "":
+			write(latinFolds[r])
+		case r >= '๐' && r <= '๙':
+			write(string('0' + r - '๐'))
+		case r == '์':
+			if lastConsonant >= 0 {
+				s := b.String()[:lastConsonant]
+				b.Reset()
+				b.WriteString(s)
+				lastConsonant = -1
+			}
+		case isThaiLeadingVowel(r) && i+1 < len(runes) && isThaiConsonant(runes[i+1]):
+			lastConsonant = b.Len()
+			write(thaiLetters[runes[i+1]])
+			b.WriteString(thaiLetters[r])
+			i++
+		case thaiLetters[r] != "":
+			if isThaiConsonant(r) {
+				lastConsonant = b.Len()
+			}
+			write(thaiLetters[r])
+		case unicode.Is(unicode.Mn, r):
+			// the accents of the decomposed Latin letters and the Thai tone marks aren't spelled
+		default:
+			hyphen = true
+			lastConsonant = -1
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if cut := strings.LastIndexByte(slug, '-'); cut > 0 {
+			slug = slug[:cut]
+		}
+	}
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,33 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Makan Ayam":                   "makan-ayam",
+		"  Crème brûlée, à la Straße!": "creme-brulee-a-la-strasse",
+		"a--b__c":                      "a-b-c",
+		"น้ำหนักน้อย / ผอม": "namhnaknoy-phom",
+		"ปกติ (สุขภาพดี)":   "pkti-sukhphaphdi",
+		"โรคอ้วนระดับ ๓":    "rokhownradab-3",
+		"Привет": "",
+	}
+	for title, slug := range tests {
+		t.Run(title, func(t *testing.T) {
+			assert.Equal(t, slug, slugify(title))
+		})
+	}
+}
+
+func TestSlugifyLongTitle(t *testing.T) {
+	slug := slugify(strings.Repeat("makan ayam ", 20))
+
+	assert.LessOrEqual(t, len(slug), maxSlugLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	assert.True(t, strings.HasSuffix(slug, "ayam") || strings.HasSuffix(slug, "makan"))
+}
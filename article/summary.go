@@ -0,0 +1,58 @@
+package article
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+const (
+	// wordsPerMinute is the reading speed the reading time is estimated with
+	wordsPerMinute = 200
+	// excerptLength is about how many bytes of plain text the excerpt keeps
+	excerptLength = 200
+)
+
+var (
+	// htmlHidden are the elements whose text isn't read
+	htmlHidden = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>`)
+	htmlTag    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// summarize derives the word count, the reading time and the excerpt of the article from its content
+func summarize(ar *domain.Article) {
+	plain := plainText(ar.Content)
+	words := strings.Fields(plain)
+
+	ar.WordCount = len(words)
+	ar.ReadingMinutes = (len(words) + wordsPerMinute - 1) / wordsPerMinute
+	ar.Excerpt = cutExcerpt(strings.Join(words, " "), excerptLength)
+}
+
+// plainText drops the tags of the HTML content and decodes its entities
+func plainText(content string) string {
+	content = htmlHidden.ReplaceAllString(content, " ")
+	return html.UnescapeString(htmlTag.ReplaceAllString(content, " "))
+}
+
+// cutExcerpt keeps about width bytes of the start of the text without splitting words,
+// an ellipsis marks the text left out
+func cutExcerpt(text string, width int) string {
+	if len(text) <= width {
+		return text
+	}
+
+	end := width
+	if text[end] != ' ' {
+		if i := strings.LastIndexByte(text[:end], ' '); i > 0 {
+			end = i
+		}
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+	}
+	return strings.TrimRight(text[:end], " ") + "…"
+}
@@ -0,0 +1,38 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestSummarize(t *testing.T) {
+	ar := domain.Article{Content: `<p>Makan <b>ayam</b>&nbsp;goreng &amp; nasi</p><script>var x = "not read";</script><style>p {}</style>`}
+	summarize(&ar)
+
+	assert.Equal(t, 5, ar.WordCount)
+	assert.Equal(t, 1, ar.ReadingMinutes)
+	assert.Equal(t, "Makan ayam goreng & nasi", ar.Excerpt)
+}
+
+func TestSummarizeLongContent(t *testing.T) {
+	ar := domain.Article{Content: "<p>" + strings.Repeat("makan ayam ", 201) + "</p>"}
+	summarize(&ar)
+
+	assert.Equal(t, 402, ar.WordCount)
+	assert.Equal(t, 3, ar.ReadingMinutes)
+	assert.LessOrEqual(t, len(ar.Excerpt), excerptLength+len("…"))
+	assert.True(t, strings.HasSuffix(ar.Excerpt, "ayam…") || strings.HasSuffix(ar.Excerpt, "makan…"))
+}
+
+func TestSummarizeEmptyContent(t *testing.T) {
+	ar := domain.Article{Content: "<p> </p>"}
+	summarize(&ar)
+
+	assert.Equal(t, 0, ar.WordCount)
+	assert.Equal(t, 0, ar.ReadingMinutes)
+	assert.Equal(t, "", ar.Excerpt)
+}
@@ -0,0 +1,58 @@
+package article
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+const (
+	// maxTagLength is the longest tag name in runes, it matches the tag.name column
+	maxTagLength = 50
+	// maxTags is how many tags an article can carry
+	maxTags = 20
+)
+
+var errTagsDisabled = fmt.Errorf("%w: tags are not enabled", domain.ErrNotFound)
+
+// foldTag brings the ways of writing a tag to a single one: lowercase, with single spaces
+func foldTag(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// normalizeTag folds the tag name and checks it fits
+func normalizeTag(name string) (string, error) {
+	name = foldTag(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: a tag can't be empty", domain.ErrBadParamInput)
+	}
+	if utf8.RuneCountInString(name) > maxTagLength {
+		return "", fmt.Errorf("%w: a tag can't be longer than %d characters", domain.ErrBadParamInput, maxTagLength)
+	}
+	return name, nil
+}
+
+// normalizeTags folds the tag names, drops the duplicates and sorts them
+func normalizeTags(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return names, nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := normalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// exportBatchSize is how many articles Export reads at once
+const exportBatchSize = 100
+
+// Import will store an article read from another environment. Unlike Store it keeps the article's
+// status and timestamps, as the article went through the workflow over there. A published article
+// without publish_at went public when it was created. With dryRun the article is only checked,
+// a title taken by another article of the same import is left unnoticed then
+func (a *Service) Import(ctx context.Context, ar *domain.Article, dryRun bool) (err error) {
+	if ar.Status == "" {
+		ar.Status = domain.StatusDraft
+	}
+	if !ar.Status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", domain.ErrBadParamInput, ar.Status)
+	}
+	switch ar.Status {
+	case domain.StatusScheduled:
+		if ar.PublishAt == nil {
+			return fmt.Errorf("%w: publish_at is required for a scheduled article", domain.ErrBadParamInput)
+		}
+	case domain.StatusPublished:
+		if ar.PublishAt == nil {
+			if ar.CreatedAt.IsZero() {
+				ar.CreatedAt = time.Now().Truncate(time.Second)
+			}
+			at := ar.CreatedAt
+			ar.PublishAt = &at
+		}
+	case domain.StatusDraft, domain.StatusInReview:
+		ar.PublishAt = nil
+	}
+	if ar.PublishAt != nil {
+		at := ar.PublishAt.Truncate(time.Second)
+		ar.PublishAt = &at
+	}
+	ar.ID = 0
+
+	if dryRun {
+		err = a.ensureAuthor(ctx, ar.Author.ID)
+		if err != nil {
+			return
+		}
+		return a.checkTitle(ctx, *ar)
+	}
+
+	err = a.store(ctx, ar)
+	if err != nil {
+		return
+	}
+	a.index(ctx, *ar)
+	return
+}
+
+// checkTitle tells whether Store would reject the title under the title policy, the title
+// must be unique within the scope the storage enforces it in
+func (a *Service) checkTitle(ctx context.Context, ar domain.Article) error {
+	if a.titlePolicy == SuffixDuplicateTitle {
+		return nil
+	}
+
+	exists, err := a.articleRepo.TitleExists(ctx, a.titleScope(ar), ar.Title)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: title %q is taken", domain.ErrConflict, ar.Title)
+	}
+	return nil
+}
+
+// Export will walk through every article along with its author, the oldest first. It stops at
+// the first error visit returns
+func (a *Service) Export(ctx context.Context, visit func(domain.Article) error) error {
+	q := domain.ArticleQuery{Num: exportBatchSize}
+	for {
+		list, page, err := a.articleRepo.Fetch(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		list, err = a.fillAuthorDetails(ctx, list)
+		if errors.Is(err, domain.ErrPartialResult) {
+			logrus.Warn(err)
+		} else if err != nil {
+			return err
+		}
+
+		for _, ar := range list { //nolint
+			err = visit(ar)
+			if err != nil {
+				return err
+			}
+		}
+
+		if page.Next == "" {
+			return nil
+		}
+		q = domain.ArticleQuery{Cursor: page.Next, Num: exportBatchSize}
+	}
+}
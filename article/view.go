@@ -0,0 +1,82 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// minTrendingWindow and maxTrendingWindow bound how far back Trending looks. The views are added
+// up by the hour, a shorter window would count the views of the whole hour it starts in
+const (
+	minTrendingWindow = time.Hour
+	maxTrendingWindow = 30 * 24 * time.Hour
+)
+
+// trendingHalfLives is how many times the views are halved over the window, the views at the start
+// of the window count for 1/16 of the views right now
+const trendingHalfLives = 4
+
+// RecordView counts a view of the article by the client, only the published articles are counted
+func (a *Service) RecordView(_ context.Context, ar domain.Article, client string) {
+	if a.viewCounter == nil || ar.Status != domain.StatusPublished {
+		return
+	}
+	a.viewCounter.Record(ar.ID, client)
+}
+
+// Trending will rank the num published articles read the most over the window, the recent views
+// weigh more than the older ones
+func (a *Service) Trending(ctx context.Context, window time.Duration, num int64) (res []domain.TrendingArticle, err error) {
+	if a.viewRepo == nil {
+		return nil, fmt.Errorf("%w: view counting is not enabled", domain.ErrNotFound)
+	}
+	if window < minTrendingWindow || window > maxTrendingWindow {
+		return nil, fmt.Errorf("%w: the window must be between %s and %s", domain.ErrBadParamInput,
+			minTrendingWindow, maxTrendingWindow)
+	}
+
+	now := time.Now()
+	ranking, err := a.viewRepo.Trending(ctx, now.Add(-window), now, window/trendingHalfLives, num)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(ranking))
+	for _, t := range ranking { //nolint
+		ids = append(ids, t.Article.ID)
+	}
+	articles, err := a.articleRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	mapArticles := make(map[int64]domain.Article, len(articles))
+	for _, ar := range articles { //nolint
+		mapArticles[ar.ID] = ar
+	}
+
+	// the articles may have been deleted or unpublished since the ranking was read
+	res = make([]domain.TrendingArticle, 0, len(ranking))
+	articles = make([]domain.Article, 0, len(ranking))
+	for _, t := range ranking { //nolint
+		ar, ok := mapArticles[t.Article.ID]
+		if !ok || ar.Status != domain.StatusPublished {
+			continue
+		}
+		t.Article = ar
+		res = append(res, t)
+		articles = append(articles, ar)
+	}
+
+	articles, err = a.fillDetails(ctx, articles)
+	if err != nil && !errors.Is(err, domain.ErrPartialResult) {
+		return nil, err
+	}
+	for index := range res {
+		res[index].Article = articles[index]
+	}
+	return
+}
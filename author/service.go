@@ -0,0 +1,80 @@
+package author
+
+import (
+	"context"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// timeFormat is how the author's timestamps are written to the datetime columns
+const timeFormat = "2006-01-02 15:04:05"
+
+// AuthorRepository represent the author's repository contract
+//
+//go:generate mockery --name AuthorRepository
+type AuthorRepository interface {
+	Fetch(ctx context.Context) ([]domain.Author, error)
+	GetByID(ctx context.Context, id int64) (domain.Author, error)
+	Store(ctx context.Context, a *domain.Author) error
+	Update(ctx context.Context, a *domain.Author) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// ArticleRepository represent the article's repository contract
+//
+//go:generate mockery --name ArticleRepository
+type ArticleRepository interface {
+	CountByAuthor(ctx context.Context, authorID int64) (int64, error)
+}
+
+type Service struct {
+	authorRepo  AuthorRepository
+	articleRepo ArticleRepository
+}
+
+// NewService will create a new author service object
+func NewService(a AuthorRepository, ar ArticleRepository) *Service {
+	return &Service{
+		authorRepo:  a,
+		articleRepo: ar,
+	}
+}
+
+func (s *Service) Fetch(ctx context.Context) ([]domain.Author, error) {
+	return s.authorRepo.Fetch(ctx)
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (domain.Author, error) {
+	return s.authorRepo.GetByID(ctx, id)
+}
+
+func (s *Service) Store(ctx context.Context, a *domain.Author) (err error) {
+	now := time.Now().Format(timeFormat)
+	a.CreatedAt = now
+	a.UpdatedAt = now
+	return s.authorRepo.Store(ctx, a)
+}
+
+func (s *Service) Update(ctx context.Context, a *domain.Author) (err error) {
+	existedAuthor, err := s.authorRepo.GetByID(ctx, a.ID)
+	if err != nil {
+		return
+	}
+
+	a.CreatedAt = existedAuthor.CreatedAt
+	a.UpdatedAt = time.Now().Format(timeFormat)
+	return s.authorRepo.Update(ctx, a)
+}
+
+// Delete will delete the author, authors that still have articles can't be deleted
+func (s *Service) Delete(ctx context.Context, id int64) (err error) {
+	total, err := s.articleRepo.CountByAuthor(ctx, id)
+	if err != nil {
+		return
+	}
+	if total > 0 {
+		return domain.ErrConflict
+	}
+	return s.authorRepo.Delete(ctx, id)
+}
@@ -0,0 +1,86 @@
+package author_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/author"
+	"github.com/bxcodec/go-clean-arch/author/mocks"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestStore(t *testing.T) {
+	mockAuthorRepo := new(mocks.AuthorRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockAuthor := domain.Author{
+		Name: "Iman Tumorang",
+	}
+
+	mockAuthorRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Author")).Return(nil).Once()
+
+	u := author.NewService(mockAuthorRepo, mockArticleRepo)
+	err := u.Store(context.TODO(), &mockAuthor)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, mockAuthor.CreatedAt)
+	assert.Equal(t, mockAuthor.CreatedAt, mockAuthor.UpdatedAt)
+	mockAuthorRepo.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	mockAuthorRepo := new(mocks.AuthorRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		existedAuthor := domain.Author{ID: 1, Name: "Iman", CreatedAt: "2017-05-18 13:50:19"}
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(existedAuthor, nil).Once()
+		mockAuthorRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Author")).Return(nil).Once()
+
+		u := author.NewService(mockAuthorRepo, mockArticleRepo)
+		a := domain.Author{ID: 1, Name: "Iman Tumorang"}
+		err := u.Update(context.TODO(), &a)
+
+		assert.NoError(t, err)
+		assert.Equal(t, existedAuthor.CreatedAt, a.CreatedAt)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("author-is-not-exist", func(t *testing.T) {
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Author{}, domain.ErrNotFound).Once()
+
+		u := author.NewService(mockAuthorRepo, mockArticleRepo)
+		err := u.Update(context.TODO(), &domain.Author{ID: 2, Name: "Iman Tumorang"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mockAuthorRepo := new(mocks.AuthorRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("CountByAuthor", mock.Anything, int64(1)).Return(int64(0), nil).Once()
+		mockAuthorRepo.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+
+		u := author.NewService(mockAuthorRepo, mockArticleRepo)
+		err := u.Delete(context.TODO(), 1)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("author-has-articles", func(t *testing.T) {
+		mockArticleRepo.On("CountByAuthor", mock.Anything, int64(2)).Return(int64(3), nil).Once()
+
+		u := author.NewService(mockAuthorRepo, mockArticleRepo)
+		err := u.Delete(context.TODO(), 2)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
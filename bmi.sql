@@ -4,5 +4,20 @@ CREATE TABLE bmi_records (
                              height DOUBLE NOT NULL,
                              weight DOUBLE NOT NULL,
                              value DOUBLE NOT NULL,
-                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+                             height_unit VARCHAR(8) NOT NULL DEFAULT 'm',
+                             weight_unit VARCHAR(8) NOT NULL DEFAULT 'kg',
+                             birth_date DATE NULL DEFAULT NULL,
+                             sex VARCHAR(8) NOT NULL DEFAULT '',
+                             waist DOUBLE NOT NULL DEFAULT 0,
+                             activity_level VARCHAR(16) NOT NULL DEFAULT '',
+                             bmr_mifflin_st_jeor DOUBLE NULL DEFAULT NULL,
+                             bmr_harris_benedict DOUBLE NULL DEFAULT NULL,
+                             tdee DOUBLE NULL DEFAULT NULL,
+                             body_fat DOUBLE NULL DEFAULT NULL,
+                             waist_to_height DOUBLE NULL DEFAULT NULL,
+                             healthy_weight_min DOUBLE NULL DEFAULT NULL,
+                             healthy_weight_max DOUBLE NULL DEFAULT NULL,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             deleted_at TIMESTAMP NULL DEFAULT NULL,
+                             INDEX (deleted_at)
 );
\ No newline at end of file
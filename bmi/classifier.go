@@ -0,0 +1,222 @@
+package bmi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// Classifier tells the codes of the weight category of a BMI value and of the health risk coming with it
+type Classifier interface {
+	Classify(value float64) (category, risk string)
+}
+
+// Labeler is a Classifier bringing labels of its own, they win over the catalog's
+type Labeler interface {
+	Label(lang, code string) (string, bool)
+}
+
+// Band is the range of BMI values from Min included to Max excluded sharing a category, its
+// category and risk are codes such as obese_class_2 and increased.
+// A zero Max leaves the band open-ended, only the last band of a scheme can be
+type Band struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max,omitempty"`
+	Category string  `json:"category"`
+	Risk     string  `json:"risk"`
+}
+
+// Scheme is a threshold table, its bands follow each other without gap nor overlap from 0 up
+type Scheme struct {
+	Name  string `json:"name"`
+	Bands []Band `json:"bands"`
+	// Messages label the codes the catalog doesn't, or differently
+	Messages Messages `json:"messages,omitempty"`
+}
+
+// Label finds the scheme's own label of the code
+func (s Scheme) Label(lang, code string) (string, bool) {
+	label, ok := s.Messages[lang][code]
+	return label, ok
+}
+
+// Classify finds the band the value falls in, the values under 0 go to the first one
+func (s Scheme) Classify(value float64) (string, string) {
+	for _, band := range s.Bands {
+		if band.Max == 0 || value < band.Max {
+			return band.Category, band.Risk
+		}
+	}
+	return "", ""
+}
+
+// Validate makes sure the bands cover every BMI value once
+func (s Scheme) Validate() error {
+	if s.Name == "" {
+		return errors.New("the scheme has no name")
+	}
+	if len(s.Bands) == 0 {
+		return fmt.Errorf("scheme %q has no band", s.Name)
+	}
+	if s.Bands[0].Min != 0 {
+		return fmt.Errorf("scheme %q: the first band must start at 0", s.Name)
+	}
+	for i, band := range s.Bands {
+		if band.Category == "" {
+			return fmt.Errorf("scheme %q: band %d has no category", s.Name, i)
+		}
+		last := i == len(s.Bands)-1
+		switch {
+		case last && band.Max != 0:
+			return fmt.Errorf("scheme %q: the last band must be open-ended", s.Name)
+		case !last && band.Max <= band.Min:
+			return fmt.Errorf("scheme %q: band %d must end above %v", s.Name, i, band.Min)
+		case !last && s.Bands[i+1].Min != band.Max:
+			return fmt.Errorf("scheme %q: band %d must start at %v, where band %d ends", s.Name, i+1, band.Max, i)
+		}
+	}
+	return nil
+}
+
+// The shipped schemes
+const (
+	SchemeWHO          = "who"
+	SchemeAsianPacific = "asian_pacific"
+	SchemeThai         = "thai"
+
+	// DefaultScheme classifies the records when the client asks for no scheme
+	DefaultScheme = SchemeThai
+)
+
+// WHO is the international classification of the World Health Organization
+var WHO = Scheme{
+	Name: SchemeWHO,
+	Bands: []Band{
+		{Min: 0, Max: 18.5, Category: "underweight", Risk: "low"},
+		{Min: 18.5, Max: 25, Category: "normal", Risk: "average"},
+		{Min: 25, Max: 30, Category: "overweight", Risk: "increased"},
+		{Min: 30, Max: 35, Category: "obese_class_1", Risk: "moderate"},
+		{Min: 35, Max: 40, Category: "obese_class_2", Risk: "severe"},
+		{Min: 40, Category: "obese_class_3", Risk: "very_severe"},
+	},
+	Messages: Messages{
+		"en": {"overweight": "Pre-obese"},
+	},
+}
+
+// AsianPacific is the WHO Western Pacific classification, with lower cut-off points for the
+// Asian populations
+var AsianPacific = Scheme{
+	Name: SchemeAsianPacific,
+	Bands: []Band{
+		{Min: 0, Max: 18.5, Category: "underweight", Risk: "low"},
+		{Min: 18.5, Max: 23, Category: "normal", Risk: "average"},
+		{Min: 23, Max: 25, Category: "overweight", Risk: "increased"},
+		{Min: 25, Max: 30, Category: "obese_class_1", Risk: "moderate"},
+		{Min: 30, Category: "obese_class_2", Risk: "severe"},
+	},
+	Messages: Messages{
+		"en": {"overweight": "At risk"},
+	},
+}
+
+// Thai is the Asian-Pacific classification as labelled by the Thai health authorities, who
+// count the obesity levels from the overweight band
+var Thai = Scheme{
+	Name:  SchemeThai,
+	Bands: AsianPacific.Bands,
+	Messages: Messages{
+		"th": {
+			"overweight":    "ท้วม / โรคอ้วนระดับ 1",
+			"obese_class_1": "อ้วน / โรคอ้วนระดับ 2",
+			"obese_class_2": "อ้วนมาก / โรคอ้วนระดับ 3",
+			"low":           "มากกว่าคนปกติ",
+			"increased":     "อันตรายระดับ 1",
+			"moderate":      "อันตรายระดับ 2",
+			"severe":        "อันตรายระดับ 3",
+		},
+	},
+}
+
+// Classifiers holds the classification schemes by name
+type Classifiers struct {
+	byName      map[string]Classifier
+	defaultName string
+}
+
+// NewClassifiers will validate the custom schemes and gather them with the shipped ones, the
+// default one classifies the records when the client asks for no scheme
+func NewClassifiers(defaultName string, custom ...Scheme) (*Classifiers, error) {
+	c := &Classifiers{
+		byName: map[string]Classifier{
+			SchemeWHO:          WHO,
+			SchemeAsianPacific: AsianPacific,
+			SchemeThai:         Thai,
+		},
+		defaultName: defaultName,
+	}
+	for _, scheme := range custom {
+		if err := scheme.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := c.byName[scheme.Name]; ok {
+			return nil, fmt.Errorf("scheme %q is defined twice", scheme.Name)
+		}
+		c.byName[scheme.Name] = scheme
+	}
+	if _, ok := c.byName[defaultName]; !ok {
+		return nil, fmt.Errorf("the default scheme %q is not defined", defaultName)
+	}
+	return c, nil
+}
+
+// Get returns the named classifier, the default one when the name is empty
+func (c *Classifiers) Get(name string) (Classifier, error) {
+	if name == "" {
+		name = c.defaultName
+	}
+	classifier, ok := c.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown classification scheme %q, use one of %s",
+			domain.ErrBadParamInput, name, strings.Join(c.Names(), ", "))
+	}
+	return classifier, nil
+}
+
+// Names lists the schemes in alphabetical order
+func (c *Classifiers) Names() []string {
+	names := make([]string, 0, len(c.byName))
+	for name := range c.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadSchemes reads custom schemes from a JSON file holding a list of schemes, each can label
+// its codes in its "messages". They're checked by NewClassifiers
+func LoadSchemes(path string) ([]Scheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schemes []Scheme
+	if err := json.Unmarshal(data, &schemes); err != nil {
+		return nil, fmt.Errorf("failed to parse the schemes of %s: %w", path, err)
+	}
+	return schemes, nil
+}
+
+// defaultClassifiers only hold the shipped schemes
+func defaultClassifiers() *Classifiers {
+	c, err := NewClassifiers(DefaultScheme)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,111 @@
+package bmi_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/bmi"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestShippedSchemes(t *testing.T) {
+	for _, scheme := range []bmi.Scheme{bmi.WHO, bmi.AsianPacific, bmi.Thai} {
+		assert.NoError(t, scheme.Validate(), scheme.Name)
+	}
+
+	tests := []struct {
+		scheme   bmi.Scheme
+		value    float64
+		category string
+	}{
+		{bmi.Thai, 22.95, "normal"},
+		{bmi.Thai, 24.95, "overweight"},
+		{bmi.Thai, 30.0, "obese_class_2"},
+		{bmi.WHO, 18.5, "normal"},
+		{bmi.WHO, 24.22, "normal"},
+		{bmi.WHO, 40, "obese_class_3"},
+		{bmi.AsianPacific, 24.22, "overweight"},
+		{bmi.AsianPacific, 0, "underweight"},
+	}
+	for _, tt := range tests {
+		category, _ := tt.scheme.Classify(tt.value)
+		assert.Equal(t, tt.category, category, "%s %v", tt.scheme.Name, tt.value)
+	}
+
+	category, risk := bmi.CalculateBMI(2, 120)
+	assert.Equal(t, "อ้วนมาก / โรคอ้วนระดับ 3", category)
+	assert.Equal(t, "อันตรายระดับ 3", risk)
+}
+
+func TestSchemeValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		bands []bmi.Band
+	}{
+		{"no band", nil},
+		{"not from 0", []bmi.Band{{Min: 10, Category: "a"}}},
+		{"gap", []bmi.Band{{Min: 0, Max: 18.5, Category: "a"}, {Min: 19, Category: "b"}}},
+		{"overlap", []bmi.Band{{Min: 0, Max: 18.5, Category: "a"}, {Min: 18, Category: "b"}}},
+		{"empty band", []bmi.Band{{Min: 0, Max: 18.5, Category: "a"}, {Min: 18.5, Max: 18.5, Category: "b"}, {Min: 18.5, Category: "c"}}},
+		{"closed", []bmi.Band{{Min: 0, Max: 18.5, Category: "a"}, {Min: 18.5, Max: 40, Category: "b"}}},
+		{"no category", []bmi.Band{{Min: 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, bmi.Scheme{Name: "custom", Bands: tt.bands}.Validate())
+		})
+	}
+}
+
+func TestNewClassifiers(t *testing.T) {
+	custom := bmi.Scheme{Name: "custom", Bands: []bmi.Band{
+		{Min: 0, Max: 20, Category: "low"},
+		{Min: 20, Category: "high"},
+	}}
+
+	classifiers, err := bmi.NewClassifiers("custom", custom)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"asian_pacific", "custom", "thai", "who"}, classifiers.Names())
+
+	classifier, err := classifiers.Get("")
+	require.NoError(t, err)
+	category, _ := classifier.Classify(20)
+	assert.Equal(t, "high", category)
+
+	_, err = classifiers.Get("unknown")
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	_, err = bmi.NewClassifiers(bmi.DefaultScheme, bmi.Scheme{Name: bmi.SchemeWHO, Bands: custom.Bands})
+	assert.Error(t, err)
+	_, err = bmi.NewClassifiers("unknown")
+	assert.Error(t, err)
+	_, err = bmi.NewClassifiers(bmi.DefaultScheme, bmi.Scheme{Name: "gap", Bands: []bmi.Band{
+		{Min: 0, Max: 20, Category: "low"},
+		{Min: 21, Category: "high"},
+	}})
+	assert.Error(t, err)
+}
+
+func TestLoadSchemes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemes.json")
+	err := os.WriteFile(path, []byte(`[{"name": "custom", "bands": [
+		{"min": 0, "max": 20, "category": "low", "risk": "none"},
+		{"min": 20, "category": "high", "risk": "some"}
+	]}]`), 0o600)
+	require.NoError(t, err)
+
+	schemes, err := bmi.LoadSchemes(path)
+	require.NoError(t, err)
+	assert.Equal(t, []bmi.Scheme{{Name: "custom", Bands: []bmi.Band{
+		{Min: 0, Max: 20, Category: "low", Risk: "none"},
+		{Min: 20, Category: "high", Risk: "some"},
+	}}}, schemes)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0o600))
+	_, err = bmi.LoadSchemes(path)
+	assert.Error(t, err)
+}
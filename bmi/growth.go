@@ -0,0 +1,209 @@
+package bmi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// The children's BMI is assessed for their age from their 2nd to their 20th birthday
+const (
+	minChildMonths = 24
+	maxChildMonths = 240
+
+	// daysPerMonth turns the days of life into months as the growth references count them
+	daysPerMonth = 30.4375
+)
+
+// SchemePediatric classifies the children's BMI-for-age percentiles
+const SchemePediatric = "pediatric"
+
+// Pediatric is the CDC classification of the BMI-for-age percentiles of the children and teens
+var Pediatric = Scheme{
+	Name: SchemePediatric,
+	Bands: []Band{
+		{Min: 0, Max: 5, Category: "underweight", Risk: "low"},
+		{Min: 5, Max: 85, Category: "normal", Risk: "average"},
+		{Min: 85, Max: 95, Category: "overweight", Risk: "increased"},
+		{Min: 95, Category: "obese", Risk: "high"},
+	},
+}
+
+// growthReferenceCSV approximates the CDC 2000 BMI-for-age reference at whole years, its LMS
+// parameters were fitted to the 5th, 50th and 95th percentiles. Load the CDC's monthly table
+// with LoadGrowthReference where precision matters
+//
+//go:embed growth_reference.csv
+var growthReferenceCSV []byte
+
+// lms are the parameters of the LMS method at an age: the skewness, the median and the
+// coefficient of variation of the BMI
+type lms struct {
+	months, l, m, s float64
+}
+
+// GrowthReference is a BMI-for-age reference by sex, each sex's parameters in age order
+type GrowthReference struct {
+	bySex map[string][]lms
+}
+
+// ParseGrowthReference reads a reference in the CSV layout of the CDC tables: a header naming
+// the Sex (1 for male, 2 for female), Agemos, L, M and S columns, the others are ignored
+func ParseGrowthReference(r io.Reader) (*GrowthReference, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the growth reference is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"Sex", "Agemos", "L", "M", "S"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the growth reference has no %s column", name)
+		}
+	}
+
+	g := &GrowthReference{bySex: map[string][]lms{}}
+	for i, record := range records[1:] {
+		var values [5]float64
+		for j, name := range []string{"Sex", "Agemos", "L", "M", "S"} {
+			values[j], err = strconv.ParseFloat(record[columns[name]], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d of the growth reference: %w", i+2, err)
+			}
+		}
+		var sex string
+		switch values[0] {
+		case 1:
+			sex = domain.SexMale
+		case 2:
+			sex = domain.SexFemale
+		default:
+			return nil, fmt.Errorf("line %d of the growth reference: unknown sex %v", i+2, values[0])
+		}
+		g.bySex[sex] = append(g.bySex[sex], lms{months: values[1], l: values[2], m: values[3], s: values[4]})
+	}
+
+	for _, sex := range []string{domain.SexMale, domain.SexFemale} {
+		points := g.bySex[sex]
+		sort.Slice(points, func(i, j int) bool { return points[i].months < points[j].months })
+		if len(points) == 0 || points[0].months > minChildMonths || points[len(points)-1].months < maxChildMonths {
+			return nil, fmt.Errorf("the growth reference must cover the %s children from %d to %d months",
+				sex, minChildMonths, maxChildMonths)
+		}
+	}
+	return g, nil
+}
+
+// LoadGrowthReference reads the reference of the CSV file, such as the CDC's bmiagerev.csv
+func LoadGrowthReference(path string) (*GrowthReference, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseGrowthReference(f)
+}
+
+// defaultGrowthReference is the embedded reference
+func defaultGrowthReference() *GrowthReference {
+	g, err := ParseGrowthReference(bytes.NewReader(growthReferenceCSV))
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// at interpolates the parameters of the sex at the age
+func (g *GrowthReference) at(sex string, months float64) lms {
+	points := g.bySex[sex]
+	i := sort.Search(len(points), func(i int) bool { return points[i].months >= months })
+	if i == 0 {
+		return points[0]
+	}
+	if i == len(points) {
+		return points[i-1]
+	}
+	lo, hi := points[i-1], points[i]
+	t := (months - lo.months) / (hi.months - lo.months)
+	return lms{
+		months: months,
+		l:      lo.l + t*(hi.l-lo.l),
+		m:      lo.m + t*(hi.m-lo.m),
+		s:      lo.s + t*(hi.s-lo.s),
+	}
+}
+
+// assess finds the z-score and the percentile of the BMI among the children of the sex and
+// age, in months
+func (g *GrowthReference) assess(value float64, sex string, months float64) (z, percentile float64) {
+	p := g.at(sex, months)
+	if p.l == 0 {
+		z = math.Log(value/p.m) / p.s
+	} else {
+		z = (math.Pow(value/p.m, p.l) - 1) / (p.l * p.s)
+	}
+	return z, 50 * math.Erfc(-z/math.Sqrt2)
+}
+
+// value is the BMI at the percentile among the children of the sex and age, in months
+func (g *GrowthReference) value(percentile float64, sex string, months float64) float64 {
+	p := g.at(sex, months)
+	z := math.Sqrt2 * math.Erfinv(percentile/50-1)
+	if p.l == 0 {
+		return p.m * math.Exp(p.s*z)
+	}
+	return p.m * math.Pow(1+p.l*p.s*z, 1/p.l)
+}
+
+// ageInMonths is how old someone born on the date is at the time
+func ageInMonths(birthDate, at time.Time) float64 {
+	return at.Sub(birthDate).Hours() / 24 / daysPerMonth
+}
+
+// isChild tells whether the BMI is assessed for the age rather than with the adult thresholds
+func isChild(months float64) bool {
+	return months >= minChildMonths && months < maxChildMonths
+}
+
+// checkBirth makes sure the birth date and the sex are usable at the time of the measurement,
+// the children need both
+func checkBirth(birthDate, sex string, at time.Time) error {
+	switch sex {
+	case "", domain.SexMale, domain.SexFemale:
+	default:
+		return fmt.Errorf("%w: unknown sex %q, use male or female", domain.ErrBadParamInput, sex)
+	}
+	if birthDate == "" {
+		return nil
+	}
+	born, err := time.Parse(time.DateOnly, birthDate)
+	if err != nil {
+		return fmt.Errorf("%w: the birth date must read as YYYY-MM-DD", domain.ErrBadParamInput)
+	}
+	months := ageInMonths(born, at)
+	switch {
+	case months < 0:
+		return fmt.Errorf("%w: the birth date is in the future", domain.ErrBadParamInput)
+	case months < minChildMonths:
+		return fmt.Errorf("%w: the BMI is not assessed under 2 years old", domain.ErrBadParamInput)
+	case isChild(months) && sex == "":
+		return fmt.Errorf("%w: the sex is needed to assess the BMI of a child", domain.ErrBadParamInput)
+	}
+	return nil
+}
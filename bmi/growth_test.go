@@ -0,0 +1,110 @@
+package bmi_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/bmi"
+	"github.com/bxcodec/go-clean-arch/bmi/mocks"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestGetBMIByIDChild(t *testing.T) {
+	measuredAt := time.Date(2024, time.June, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		record     domain.BMI
+		scheme     string
+		category   string
+		percentile float64
+	}{
+		{"median boy", domain.BMI{Value: 16.6, BirthDate: "2014-06-01", Sex: "male"}, "pediatric", "normal", 50},
+		{"obese girl", domain.BMI{Value: 24, BirthDate: "2014-06-01", Sex: "female"}, "pediatric", "obese", 97},
+		{"thin teen", domain.BMI{Value: 16, BirthDate: "2008-06-01", Sex: "male"}, "pediatric", "underweight", 2},
+		{"adult", domain.BMI{Value: 24, BirthDate: "1990-06-01", Sex: "female"}, "who", "normal", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+			record := tt.record
+			record.CreatedAt = measuredAt
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&record, nil).Once()
+
+			result, err := service.GetBMIByID(context.Background(), 1, domain.BMIOptions{Scheme: "who", Languages: []string{"en"}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.scheme, result.Scheme)
+			assert.Equal(t, tt.category, result.CategoryCode)
+			if tt.percentile == 0 {
+				assert.Nil(t, result.Percentile)
+				assert.Nil(t, result.ZScore)
+				return
+			}
+			require.NotNil(t, result.Percentile)
+			require.NotNil(t, result.ZScore)
+			assert.InDelta(t, tt.percentile, *result.Percentile, 2)
+		})
+	}
+}
+
+func TestCalculateAndStoreBMIBirth(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  domain.BMICalculationRequest
+	}{
+		{"child without sex", domain.BMICalculationRequest{BirthDate: now.AddDate(-8, 0, 0).Format(time.DateOnly)}},
+		{"toddler", domain.BMICalculationRequest{BirthDate: now.AddDate(-1, 0, 0).Format(time.DateOnly), Sex: "male"}},
+		{"unborn", domain.BMICalculationRequest{BirthDate: now.AddDate(1, 0, 0).Format(time.DateOnly), Sex: "male"}},
+		{"malformed date", domain.BMICalculationRequest{BirthDate: "01/06/2014", Sex: "male"}},
+		{"unknown sex", domain.BMICalculationRequest{Sex: "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+
+			req := tt.req
+			req.Height, req.Weight = 1.3, 27
+			_, err := service.CalculateAndStoreBMI(context.Background(), req, domain.BMIOptions{})
+			assert.ErrorIs(t, err, domain.ErrBadParamInput)
+			mockRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+		})
+	}
+
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+	mockRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.BMI")).Return(nil).Once()
+
+	req := domain.BMICalculationRequest{Height: 1.3, Weight: 27, BirthDate: now.AddDate(-8, 0, 0).Format(time.DateOnly), Sex: "female"}
+	result, err := service.CalculateAndStoreBMI(context.Background(), req, domain.BMIOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "pediatric", result.Scheme)
+	assert.Equal(t, "female", result.Sex)
+	assert.NotNil(t, result.Percentile)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestParseGrowthReference(t *testing.T) {
+	reference := `Sex,Agemos,L,M,S,P50
+1,24,-2,16.5,0.08,16.5
+1,240,-2,22.6,0.13,22.6
+2,24,-1,16.4,0.085,16.4
+2,240,-2.5,21.7,0.15,21.7
+`
+	g, err := bmi.ParseGrowthReference(strings.NewReader(reference))
+	require.NoError(t, err)
+	assert.NotNil(t, g)
+
+	_, err = bmi.ParseGrowthReference(strings.NewReader("Sex,Agemos,L,M\n1,24,-2,16.5\n"))
+	assert.Error(t, err)
+
+	_, err = bmi.ParseGrowthReference(strings.NewReader("Sex,Agemos,L,M,S\n1,24,-2,16.5,0.08\n2,24,-1,16.4,0.085\n"))
+	assert.Error(t, err)
+}
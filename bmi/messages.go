@@ -0,0 +1,86 @@
+package bmi
+
+import (
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// DefaultLanguage labels the records when the client accepts none of the languages they're labelled in
+const DefaultLanguage = "th"
+
+// Messages are the labels of the category and risk codes, by language then by code
+type Messages map[string]map[string]string
+
+// catalog labels the codes of the shipped schemes, the schemes may label them differently
+var catalog = Messages{
+	"en": {
+		"underweight":   "Underweight",
+		"normal":        "Normal weight",
+		"overweight":    "Overweight",
+		"obese_class_1": "Obese class I",
+		"obese_class_2": "Obese class II",
+		"obese_class_3": "Obese class III",
+		"obese":         "Obesity",
+		"low":           "Low, but risk of other clinical problems increased",
+		"average":       "Average",
+		"increased":     "Increased",
+		"moderate":      "Moderate",
+		"severe":        "Severe",
+		"very_severe":   "Very severe",
+		"high":          "High",
+	},
+	"th": {
+		"underweight":   "น้ำหนักน้อย / ผอม",
+		"normal":        "ปกติ (สุขภาพดี)",
+		"overweight":    "น้ำหนักเกิน",
+		"obese_class_1": "โรคอ้วนระดับ 1",
+		"obese_class_2": "โรคอ้วนระดับ 2",
+		"obese_class_3": "โรคอ้วนระดับ 3",
+		"obese":         "โรคอ้วน",
+		"low":           "ต่ำ แต่เสี่ยงต่อปัญหาสุขภาพอื่นเพิ่มขึ้น",
+		"average":       "เท่าคนปกติ",
+		"increased":     "เพิ่มขึ้น",
+		"moderate":      "ปานกลาง",
+		"severe":        "รุนแรง",
+		"very_severe":   "รุนแรงมาก",
+		"high":          "สูง",
+	},
+}
+
+// translate finds the label of the code in the language, the classifier's own first
+func translate(classifier Classifier, lang, code string) (string, bool) {
+	if labeler, ok := classifier.(Labeler); ok {
+		if label, ok := labeler.Label(lang, code); ok {
+			return label, true
+		}
+	}
+	label, ok := catalog[lang][code]
+	return label, ok
+}
+
+// label finds the label of the code in the language, then in the default language, at last
+// the code itself stands for its label
+func label(classifier Classifier, lang, code string) string {
+	for _, l := range []string{lang, DefaultLanguage} {
+		if label, ok := translate(classifier, l, code); ok {
+			return label
+		}
+	}
+	return code
+}
+
+// localize labels the record in the first of the languages its category has a label in, the
+// default language otherwise. The languages are in the client's order of preference
+func localize(bmi *domain.BMI, classifier Classifier, languages []string) {
+	bmi.Language = DefaultLanguage
+	for _, lang := range languages {
+		lang = strings.ToLower(lang)
+		if _, ok := translate(classifier, lang, bmi.CategoryCode); ok {
+			bmi.Language = lang
+			break
+		}
+	}
+	bmi.Category = label(classifier, bmi.Language, bmi.CategoryCode)
+	bmi.Risk = label(classifier, bmi.Language, bmi.RiskCode)
+}
@@ -0,0 +1,152 @@
+package bmi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// activityFactors multiply the BMR into the total daily energy expenditure, by activity level
+var activityFactors = map[string]float64{
+	domain.ActivitySedentary:  1.2,
+	domain.ActivityLight:      1.375,
+	domain.ActivityModerate:   1.55,
+	domain.ActivityActive:     1.725,
+	domain.ActivityVeryActive: 1.9,
+}
+
+const (
+	// adultMonths is the age the BMR equations hold from, at 18
+	adultMonths = 216
+	// adultBodyFatMonths is the age the body fat is estimated with the adults' equation from, at 16
+	adultBodyFatMonths = 192
+
+	minWaist = 0.3 // m
+	maxWaist = 2.5 // m
+)
+
+// measureWaist converts the waist circumference to meters, none is 0. It must be a plausible one
+func measureWaist(waist float64, unit string) (float64, error) {
+	switch unit {
+	case "", domain.UnitMeter:
+	case domain.UnitCentimeter:
+		waist *= metersPerCentimeter
+	case domain.UnitInch:
+		waist *= metersPerInch
+	default:
+		return 0, fmt.Errorf("%w: unknown waist unit %q, use m, cm or in", domain.ErrBadParamInput, unit)
+	}
+	if waist != 0 && (waist < minWaist || waist > maxWaist) {
+		return 0, fmt.Errorf("%w: a waist of %.2f m is not plausible, it must be between %v and %v m",
+			domain.ErrBadParamInput, waist, minWaist, maxWaist)
+	}
+	return waist, nil
+}
+
+// checkActivity makes sure the activity level is known, none is empty
+func checkActivity(level string) error {
+	if _, ok := activityFactors[level]; ok || level == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: unknown activity level %q, use sedentary, light, moderate, active or very_active",
+		domain.ErrBadParamInput, level)
+}
+
+// ageAt is how old, in months, the person was at the measurement, when their birth date is known
+func ageAt(bmi *domain.BMI) (float64, bool) {
+	born, err := time.Parse(time.DateOnly, bmi.BirthDate)
+	if err != nil {
+		return 0, false
+	}
+	return ageInMonths(born, bmi.CreatedAt), true
+}
+
+// assessBody estimates the body metrics of the record in meters and kilograms. The BMR and the
+// body fat need the age and the sex, the TDEE the activity level too
+func (p presentation) assessBody(bmi *domain.BMI) {
+	metrics := &domain.BodyMetrics{}
+	if bmi.Waist > 0 {
+		ratio := bmi.Waist / bmi.Height
+		metrics.WaistToHeight = &ratio
+	}
+
+	months, aged := ageAt(bmi)
+	if aged && bmi.Sex != "" {
+		years := months / 12
+		if months >= adultMonths {
+			mifflin, harris := basalMetabolicRates(bmi.Weight, bmi.Height, years, bmi.Sex)
+			metrics.BMRMifflinStJeor, metrics.BMRHarrisBenedict = &mifflin, &harris
+			if factor, ok := activityFactors[bmi.ActivityLevel]; ok {
+				tdee := mifflin * factor
+				metrics.TDEE = &tdee
+			}
+		}
+		bodyFat := bodyFatPercentage(bmi.Value, years, bmi.Sex)
+		metrics.BodyFat = &bodyFat
+	}
+
+	metrics.HealthyWeightMin, metrics.HealthyWeightMax = p.healthyWeights(bmi, months, aged)
+	bmi.Metrics = metrics
+}
+
+// basalMetabolicRates are the kcal a day burnt at rest by the Mifflin-St Jeor and the revised
+// Harris-Benedict equations, of the weight in kilograms and the height in meters
+func basalMetabolicRates(weight, height, years float64, sex string) (mifflin, harris float64) {
+	centimeters := height / metersPerCentimeter
+	mifflin = 10*weight + 6.25*centimeters - 5*years
+	if sex == domain.SexMale {
+		return mifflin + 5, 88.362 + 13.397*weight + 4.799*centimeters - 5.677*years
+	}
+	return mifflin - 161, 447.593 + 9.247*weight + 3.098*centimeters - 4.330*years
+}
+
+// bodyFatPercentage estimates the body fat from the BMI with the Deurenberg equations, the
+// children's one under 16
+func bodyFatPercentage(value, years float64, sex string) float64 {
+	male := 0.0
+	if sex == domain.SexMale {
+		male = 1
+	}
+	if years*12 < adultBodyFatMonths {
+		return 1.51*value - 0.70*years - 3.6*male + 1.4
+	}
+	return 1.20*value + 0.23*years - 10.8*male - 5.4
+}
+
+// healthyWeights bound the weights of a normal BMI at the record's height, by the scheme's
+// normal band for the adults and by the percentiles of the pediatric one for the children
+func (p presentation) healthyWeights(bmi *domain.BMI, months float64, aged bool) (*float64, *float64) {
+	squared := bmi.Height * bmi.Height
+	if aged && bmi.Sex != "" && isChild(months) {
+		band, _ := normalBand(Pediatric)
+		low := p.growth.value(band.Min, bmi.Sex, months) * squared
+		high := p.growth.value(band.Max, bmi.Sex, months) * squared
+		return &low, &high
+	}
+
+	band, ok := normalBand(p.classifier)
+	if !ok {
+		return nil, nil
+	}
+	low := band.Min * squared
+	if band.Max == 0 {
+		return &low, nil
+	}
+	high := band.Max * squared
+	return &low, &high
+}
+
+// normalBand finds the band of the normal weight, the classifiers that aren't a Scheme have none
+func normalBand(classifier Classifier) (Band, bool) {
+	scheme, ok := classifier.(Scheme)
+	if !ok {
+		return Band{}, false
+	}
+	for _, band := range scheme.Bands {
+		if band.Category == "normal" {
+			return band, true
+		}
+	}
+	return Band{}, false
+}
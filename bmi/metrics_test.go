@@ -0,0 +1,154 @@
+package bmi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/bmi"
+	"github.com/bxcodec/go-clean-arch/bmi/mocks"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestCalculateAndStoreBMIMetrics(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+	mockRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.BMI")).Return(nil).Run(func(args mock.Arguments) {
+		stored := args.Get(1).(*domain.BMI)
+		require.NotNil(t, stored.Metrics)
+		assert.InDelta(t, 0.9, stored.Waist, 0.001)
+		assert.Equal(t, "moderate", stored.ActivityLevel)
+	}).Once()
+
+	req := domain.BMICalculationRequest{
+		Height: 180, HeightUnit: "cm", Weight: 80,
+		BirthDate: time.Now().AddDate(-34, 0, 0).Format(time.DateOnly), Sex: "male",
+		Waist: 90, WaistUnit: "cm", ActivityLevel: "moderate",
+	}
+	result, err := service.CalculateAndStoreBMI(context.Background(), req, domain.BMIOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Metrics)
+
+	metrics := result.Metrics
+	assert.InDelta(t, 1760, *metrics.BMRMifflinStJeor, 1)
+	assert.InDelta(t, 1831, *metrics.BMRHarrisBenedict, 1)
+	assert.InDelta(t, 2728, *metrics.TDEE, 2)
+	assert.InDelta(t, 21.25, *metrics.BodyFat, 0.1)
+	assert.InDelta(t, 0.5, *metrics.WaistToHeight, 0.001)
+	assert.InDelta(t, 59.94, *metrics.HealthyWeightMin, 0.01)
+	assert.InDelta(t, 74.52, *metrics.HealthyWeightMax, 0.01)
+	assert.Equal(t, "m", result.WaistUnit)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCalculateAndStoreBMIMetricsPartial(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.BMICalculationRequest
+		opts    domain.BMIOptions
+		bmr     bool
+		bodyFat bool
+		max     float64
+	}{
+		{"no birth date", domain.BMICalculationRequest{Sex: "female", ActivityLevel: "active"}, domain.BMIOptions{}, false, false, 65.69},
+		{"who scheme", domain.BMICalculationRequest{}, domain.BMIOptions{Scheme: "who"}, false, false, 71.40},
+		{"child", domain.BMICalculationRequest{BirthDate: time.Now().AddDate(-10, 0, 0).Format(time.DateOnly), Sex: "female"},
+			domain.BMIOptions{}, false, true, 0},
+		{"adult", domain.BMICalculationRequest{BirthDate: time.Now().AddDate(-40, 0, 0).Format(time.DateOnly), Sex: "female"},
+			domain.BMIOptions{}, true, true, 65.69},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+			mockRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.BMI")).Return(nil).Once()
+
+			req := tt.req
+			req.Height, req.Weight = 1.69, 55
+			result, err := service.CalculateAndStoreBMI(context.Background(), req, tt.opts)
+			require.NoError(t, err)
+
+			metrics := result.Metrics
+			require.NotNil(t, metrics)
+			assert.Equal(t, tt.bmr, metrics.BMRMifflinStJeor != nil)
+			assert.Equal(t, tt.bmr, metrics.BMRHarrisBenedict != nil)
+			assert.Nil(t, metrics.TDEE)
+			assert.Nil(t, metrics.WaistToHeight)
+			assert.Equal(t, tt.bodyFat, metrics.BodyFat != nil)
+			require.NotNil(t, metrics.HealthyWeightMin)
+			require.NotNil(t, metrics.HealthyWeightMax)
+			assert.Less(t, *metrics.HealthyWeightMin, *metrics.HealthyWeightMax)
+			if tt.max != 0 {
+				assert.InDelta(t, tt.max, *metrics.HealthyWeightMax, 0.01)
+			}
+		})
+	}
+}
+
+func TestCalculateAndStoreBMIMetricsImperial(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+	mockRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.BMI")).Return(nil).Once()
+
+	req := domain.BMICalculationRequest{Height: 1.8, Weight: 80, Waist: 36, WaistUnit: "in"}
+	result, err := service.CalculateAndStoreBMI(context.Background(), req, domain.BMIOptions{Units: domain.UnitsImperial})
+	require.NoError(t, err)
+	assert.InDelta(t, 36, result.Waist, 0.001)
+	assert.Equal(t, "in", result.WaistUnit)
+	assert.InDelta(t, 59.94/0.45359237, *result.Metrics.HealthyWeightMin, 0.01)
+	assert.InDelta(t, 74.52/0.45359237, *result.Metrics.HealthyWeightMax, 0.01)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCalculateAndStoreBMIMetricsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  domain.BMICalculationRequest
+	}{
+		{"unknown activity", domain.BMICalculationRequest{ActivityLevel: "couch"}},
+		{"waist in centimeters as meters", domain.BMICalculationRequest{Waist: 90}},
+		{"unknown waist unit", domain.BMICalculationRequest{Waist: 3, WaistUnit: "ft"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+
+			req := tt.req
+			req.Height, req.Weight = 1.8, 80
+			_, err := service.CalculateAndStoreBMI(context.Background(), req, domain.BMIOptions{})
+			assert.ErrorIs(t, err, domain.ErrBadParamInput)
+			mockRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateBMIMetrics(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+
+	measuredAt := time.Date(2020, time.June, 1, 10, 0, 0, 0, time.UTC)
+	record := &domain.BMI{ID: 1, Height: 1.8, Weight: 80, Waist: 81, WaistUnit: "cm", BirthDate: "1990-06-01", Sex: "male",
+		ActivityLevel: "sedentary"}
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.BMI{ID: 1, CreatedAt: measuredAt}, nil).Once()
+	mockRepo.On("Update", mock.Anything, record).Return(nil).Once()
+
+	err := service.UpdateBMI(context.Background(), record)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.81, record.Waist, 0.001)
+	// the metrics are those of the age at the stored measurement
+	assert.Equal(t, measuredAt, record.CreatedAt)
+	require.NotNil(t, record.Metrics)
+	assert.InDelta(t, 0.45, *record.Metrics.WaistToHeight, 0.001)
+	assert.InDelta(t, 1780, *record.Metrics.BMRMifflinStJeor, 0.5)
+	assert.InDelta(t, *record.Metrics.BMRMifflinStJeor*1.2, *record.Metrics.TDEE, 0.001)
+
+	mockRepo.On("GetByID", mock.Anything, int64(2)).Return((*domain.BMI)(nil), domain.ErrNotFound).Once()
+	err = service.UpdateBMI(context.Background(), &domain.BMI{ID: 2, Height: 1.8, Weight: 80})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
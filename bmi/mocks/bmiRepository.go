@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"context"
+	"time"
+
 	"github.com/bxcodec/go-clean-arch/domain"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,7 +32,22 @@ func (m *MockBMIRepository) Update(ctx context.Context, bmi *domain.BMI) error {
 	return args.Error(0)
 }
 
-func (m *MockBMIRepository) Delete(ctx context.Context, id int64) error {
+func (m *MockBMIRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockBMIRepository) Restore(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockBMIRepository) GetTrash(ctx context.Context) ([]*domain.BMI, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.BMI), args.Error(1)
+}
+
+func (m *MockBMIRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -13,7 +13,10 @@ type bmiRepository interface {
 	GetByID(ctx context.Context, id int64) (*domain.BMI, error)
 	GetAll(ctx context.Context) ([]*domain.BMI, error)
 	Update(ctx context.Context, bmi *domain.BMI) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, deletedAt time.Time) error
+	Restore(ctx context.Context, id int64) error
+	GetTrash(ctx context.Context) ([]*domain.BMI, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
 
 type bmiQdrantRepository interface {
@@ -25,119 +28,276 @@ type bmiQdrantRepository interface {
 type Service struct {
 	bmiRepo       bmiRepository
 	bmiQdrantRepo bmiQdrantRepository
+	classifiers   *Classifiers
+	growth        *GrowthReference
 }
 
-func NewServices(b bmiRepository, bq bmiQdrantRepository) *Service {
-	return &Service{
+// Option tunes the Service
+type Option func(*Service)
+
+// WithClassifiers sets the classification schemes the clients choose from, the shipped ones by default
+func WithClassifiers(c *Classifiers) Option {
+	return func(s *Service) {
+		s.classifiers = c
+	}
+}
+
+// WithGrowthReference sets the BMI-for-age reference the children are assessed with, the embedded one by default
+func WithGrowthReference(g *GrowthReference) Option {
+	return func(s *Service) {
+		s.growth = g
+	}
+}
+
+func NewServices(b bmiRepository, bq bmiQdrantRepository, opts ...Option) *Service {
+	s := &Service{
 		bmiRepo:       b,
 		bmiQdrantRepo: bq,
+		classifiers:   defaultClassifiers(),
+		growth:        defaultGrowthReference(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-func (u *Service) CalculateAndStoreBMI(ctx context.Context, height, weight float64) (*domain.BMI, error) {
-	if height <= 0 {
-		return nil, fmt.Errorf("height must be greater than 0")
+func (u *Service) CalculateAndStoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error) {
+	p, err := u.presentation(opts)
+	if err != nil {
+		return nil, err
 	}
-	value := weight / (height * height)
-	bmi := &domain.BMI{
-		Height:    height,
-		Weight:    weight,
-		Value:     value,
-		CreatedAt: time.Now(),
+	bmi, err := newBMI(req, time.Now())
+	if err != nil {
+		return nil, err
 	}
-	err := u.bmiRepo.Store(ctx, bmi)
+	p.assessBody(bmi)
+	err = u.bmiRepo.Store(ctx, bmi)
 	if err != nil {
 		return nil, err
 	}
+	p.present(bmi)
 	return bmi, nil
 }
 
-func CalculateBMICategoryAndRisk(value float64) (string, string) {
-	switch {
-	case value < 18.5:
-		return "น้ำหนักน้อย / ผอม", "มากกว่าคนปกติ"
-	case value >= 18.5 && value <= 22.9:
-		return "ปกติ (สุขภาพดี)", "เท่าคนปกติ"
-	case value >= 23 && value <= 24.9:
-		return "ท้วม / โรคอ้วนระดับ 1", "อันตรายระดับ 1"
-	case value >= 25 && value <= 29.9:
-		return "อ้วน / โรคอ้วนระดับ 2", "อันตรายระดับ 2"
-	case value > 30:
-		return "อ้วนมาก / โรคอ้วนระดับ 3", "อันตรายระดับ 3"
-	default:
-		return "", ""
+// newBMI calculates the BMI of the measurements taken at the time, converted to meters and kilograms
+func newBMI(req domain.BMICalculationRequest, at time.Time) (*domain.BMI, error) {
+	height, weight, err := measure(req.Height, req.HeightInches, req.HeightUnit, req.Weight, req.WeightUnit)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBirth(req.BirthDate, req.Sex, at); err != nil {
+		return nil, err
+	}
+	waist, err := measureWaist(req.Waist, req.WaistUnit)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkActivity(req.ActivityLevel); err != nil {
+		return nil, err
+	}
+	return &domain.BMI{
+		Height:            height,
+		Weight:            weight,
+		Value:             weight / (height * height),
+		EnteredHeightUnit: orDefault(req.HeightUnit, domain.UnitMeter),
+		EnteredWeightUnit: orDefault(req.WeightUnit, domain.UnitKilogram),
+		BirthDate:         req.BirthDate,
+		Sex:               req.Sex,
+		Waist:             waist,
+		ActivityLevel:     req.ActivityLevel,
+		CreatedAt:         at,
+	}, nil
+}
+
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
 	}
+	return value
 }
 
+// CalculateBMICategoryAndRisk classifies the value with the Thai scheme, in Thai
+func CalculateBMICategoryAndRisk(value float64) (string, string) {
+	category, risk := Thai.Classify(value)
+	return label(Thai, "th", category), label(Thai, "th", risk)
+}
+
+// CalculateBMI classifies the BMI of the height in meters and the weight in kilograms with the Thai scheme, in Thai
 func CalculateBMI(height, weight float64) (string, string) {
-	bmi := weight / (height * height)
+	return CalculateBMICategoryAndRisk(weight / (height * height))
+}
+
+// presentation is how the client wants the records
+type presentation struct {
+	scheme     string
+	classifier Classifier
+	languages  []string
+	units      string
+	growth     *GrowthReference
+}
+
+// presentation checks the options of the client, the classification scheme they ask for
+// must be known
+func (u *Service) presentation(opts domain.BMIOptions) (presentation, error) {
+	p := presentation{
+		scheme:    orDefault(opts.Scheme, u.classifiers.defaultName),
+		languages: opts.Languages,
+		units:     opts.Units,
+		growth:    u.growth,
+	}
+	var err error
+	p.classifier, err = u.classifiers.Get(p.scheme)
+	if err != nil {
+		return p, err
+	}
+	return p, checkUnits(p.units)
+}
 
-	switch {
-	case bmi < 18.5:
-		return "น้ำหนักน้อย / ผอม", "มากกว่าคนปกติ"
-	case bmi >= 18.5 && bmi <= 22.9:
-		return "ปกติ (สุขภาพดี)", "เท่าคนปกติ"
-	case bmi >= 23 && bmi <= 24.9:
-		return "ท้วม / โรคอ้วนระดับ 1", "อันตรายระดับ 1"
-	case bmi >= 25 && bmi <= 29.9:
-		return "อ้วน / โรคอ้วนระดับ 2", "อันตรายระดับ 2"
-	case bmi >= 30:
-		return "อ้วนมาก / โรคอ้วนระดับ 3", "อันตรายระดับ 3"
-	default:
-		return "", ""
+// classify sets the category and the risk of the record from the scheme, labelled in the
+// language the client prefers. The children's BMI is classified by its percentile for their
+// age and sex instead
+func (p presentation) classify(bmi *domain.BMI) {
+	scheme, classifier, value := p.scheme, p.classifier, bmi.Value
+	if months, ok := ageAt(bmi); ok && bmi.Sex != "" && isChild(months) {
+		z, percentile := p.growth.assess(bmi.Value, bmi.Sex, months)
+		bmi.ZScore, bmi.Percentile = &z, &percentile
+		scheme, classifier, value = SchemePediatric, Pediatric, percentile
 	}
+	bmi.CategoryCode, bmi.RiskCode = classifier.Classify(value)
+	bmi.Scheme = scheme
+	localize(bmi, classifier, p.languages)
+}
+
+// present classifies the record read in meters and kilograms, then converts it to the client's units
+func (p presentation) present(bmi *domain.BMI) {
+	p.classify(bmi)
+	convert(bmi, p.units)
 }
 
-func (u *Service) GetBMIByID(ctx context.Context, id int64) (*domain.BMI, error) {
+func (u *Service) GetBMIByID(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error) {
+	p, err := u.presentation(opts)
+	if err != nil {
+		return nil, err
+	}
 	bmi, err := u.bmiRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	bmi.Category, bmi.Risk = CalculateBMICategoryAndRisk(bmi.Value)
+	p.present(bmi)
 
 	return bmi, nil
 }
 
-func (u *Service) GetAllBMI(ctx context.Context) ([]*domain.BMI, error) {
+func (u *Service) GetAllBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error) {
+	p, err := u.presentation(opts)
+	if err != nil {
+		return nil, err
+	}
 	bmiRecords, err := u.bmiRepo.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, bmi := range bmiRecords {
-		bmi.Category, bmi.Risk = CalculateBMICategoryAndRisk(bmi.Value)
+		p.present(bmi)
 	}
 
 	return bmiRecords, nil
 }
 
+// UpdateBMI replaces the measurements of the record, given in its HeightUnit, WeightUnit and
+// WaistUnit. The body metrics are estimated again with the default scheme, for the age at the
+// stored measurement date since it isn't updated
 func (u *Service) UpdateBMI(ctx context.Context, bmi *domain.BMI) error {
 	if bmi.Height <= 0 || bmi.Weight <= 0 {
-		return fmt.Errorf("height and weight must be greater than 0")
+		return fmt.Errorf("%w: height and weight must be greater than 0", domain.ErrBadParamInput)
+	}
+	p, err := u.presentation(domain.BMIOptions{})
+	if err != nil {
+		return err
+	}
+	height, weight, err := measure(bmi.Height, bmi.HeightInches, bmi.HeightUnit, bmi.Weight, bmi.WeightUnit)
+	if err != nil {
+		return err
+	}
+	waist, err := measureWaist(bmi.Waist, bmi.WaistUnit)
+	if err != nil {
+		return err
+	}
+	if err := checkActivity(bmi.ActivityLevel); err != nil {
+		return err
+	}
+	stored, err := u.bmiRepo.GetByID(ctx, bmi.ID)
+	if err != nil {
+		return err
+	}
+	bmi.CreatedAt = stored.CreatedAt
+	if err := checkBirth(bmi.BirthDate, bmi.Sex, bmi.CreatedAt); err != nil {
+		return err
 	}
-	bmi.Value = bmi.Weight / (bmi.Height * bmi.Height)
+	bmi.EnteredHeightUnit = orDefault(bmi.HeightUnit, domain.UnitMeter)
+	bmi.EnteredWeightUnit = orDefault(bmi.WeightUnit, domain.UnitKilogram)
+	bmi.Height, bmi.HeightInches, bmi.HeightUnit = height, 0, domain.UnitMeter
+	bmi.Weight, bmi.WeightUnit = weight, domain.UnitKilogram
+	bmi.Waist, bmi.WaistUnit = waist, domain.UnitMeter
+	bmi.Value = weight / (height * height)
+	p.assessBody(bmi)
 	return u.bmiRepo.Update(ctx, bmi)
 }
 
+// DeleteBMI moves the record to the trash, it can be restored until it's purged
 func (u *Service) DeleteBMI(ctx context.Context, id int64) error {
-	return u.bmiRepo.Delete(ctx, id)
+	return u.bmiRepo.Delete(ctx, id, time.Now())
 }
 
-func (u *Service) StoreBMI(ctx context.Context, height, weight float64) (*domain.BMI, error) {
-	if height <= 0 {
-		return nil, fmt.Errorf("height must be greater than 0")
+// RestoreBMI takes the record out of the trash
+func (u *Service) RestoreBMI(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error) {
+	if _, err := u.presentation(opts); err != nil {
+		return nil, err
+	}
+	if err := u.bmiRepo.Restore(ctx, id); err != nil {
+		return nil, err
 	}
+	return u.GetBMIByID(ctx, id, opts)
+}
 
-	value := weight / (height * height)
-	bmi := &domain.BMI{
-		Height:    height,
-		Weight:    weight,
-		Value:     value,
-		CreatedAt: time.Now(),
+// GetTrashedBMI lists the records in the trash, the latest deleted first
+func (u *Service) GetTrashedBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error) {
+	p, err := u.presentation(opts)
+	if err != nil {
+		return nil, err
+	}
+	bmiRecords, err := u.bmiRepo.GetTrash(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	bmi.Category, bmi.Risk = CalculateBMICategoryAndRisk(value)
+	for _, bmi := range bmiRecords {
+		p.present(bmi)
+	}
+
+	return bmiRecords, nil
+}
+
+// PurgeTrashedBMI permanently removes the records trashed before the given time
+func (u *Service) PurgeTrashedBMI(ctx context.Context, before time.Time) (int64, error) {
+	return u.bmiRepo.Purge(ctx, before)
+}
+
+func (u *Service) StoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error) {
+	p, err := u.presentation(opts)
+	if err != nil {
+		return nil, err
+	}
+	bmi, err := newBMI(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	p.classify(bmi)
+	p.assessBody(bmi)
 
 	if err := u.bmiRepo.Store(ctx, bmi); err != nil {
 		return nil, fmt.Errorf("failed to store BMI in MySQL: %w", err)
@@ -147,13 +307,36 @@ func (u *Service) StoreBMI(ctx context.Context, height, weight float64) (*domain
 		return nil, fmt.Errorf("failed to store BMI in Qdrant: %w", err)
 	}
 
+	convert(bmi, p.units)
 	return bmi, nil
 }
 
-func (u *Service) QueryBMI(ctx context.Context, queryVector []float32) ([]*client.ScoredPoint, error) {
+func (u *Service) QueryBMI(ctx context.Context, queryVector []float32) ([]*domain.BMI, error) {
 	results, err := u.bmiQdrantRepo.Query(ctx, queryVector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query BMI from Qdrant: %w", err)
 	}
-	return results, nil
+
+	bmis := make([]*domain.BMI, 0, len(results))
+	for _, point := range results {
+		bmis = append(bmis, scoredPointToBMI(point))
+	}
+	return bmis, nil
+}
+
+func scoredPointToBMI(point *client.ScoredPoint) *domain.BMI {
+	bmi := &domain.BMI{
+		ID:           int64(point.GetId().GetNum()),
+		Category:     point.GetPayload()["category"].GetStringValue(),
+		Risk:         point.GetPayload()["risk"].GetStringValue(),
+		Scheme:       point.GetPayload()["scheme"].GetStringValue(),
+		CategoryCode: point.GetPayload()["category_code"].GetStringValue(),
+		RiskCode:     point.GetPayload()["risk_code"].GetStringValue(),
+		Language:     point.GetPayload()["language"].GetStringValue(),
+	}
+	createdAt, err := time.Parse(time.RFC3339, point.GetPayload()["created_at"].GetStringValue())
+	if err == nil {
+		bmi.CreatedAt = createdAt
+	}
+	return bmi
 }
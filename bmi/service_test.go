@@ -13,7 +13,7 @@ import (
 
 func TestCalculateAndStoreBMI(t *testing.T) {
 	mockRepo := new(mocks.MockBMIRepository)
-	service := bmi.NewServices(mockRepo)
+	service := bmi.NewServices(mockRepo, nil)
 
 	height := 1.70
 	weight := 70.0
@@ -32,7 +32,7 @@ func TestCalculateAndStoreBMI(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	bmiResult, err := service.CalculateAndStoreBMI(ctx, height, weight)
+	bmiResult, err := service.CalculateAndStoreBMI(ctx, domain.BMICalculationRequest{Height: height, Weight: weight}, domain.BMIOptions{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, bmiResult)
@@ -119,7 +119,7 @@ func TestCalculateBMICategoryAndRisk(t *testing.T) {
 
 func TestGetBMIByID(t *testing.T) {
 	mockRepo := new(mocks.MockBMIRepository)
-	service := bmi.NewServices(mockRepo)
+	service := bmi.NewServices(mockRepo, nil)
 
 	expectedBMI := &domain.BMI{
 		ID:        1,
@@ -132,7 +132,7 @@ func TestGetBMIByID(t *testing.T) {
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(expectedBMI, nil)
 
 	ctx := context.Background()
-	result, err := service.GetBMIByID(ctx, 1)
+	result, err := service.GetBMIByID(ctx, 1, domain.BMIOptions{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -145,7 +145,7 @@ func TestGetBMIByID(t *testing.T) {
 
 func TestGetAllBMI(t *testing.T) {
 	mockRepo := new(mocks.MockBMIRepository)
-	service := bmi.NewServices(mockRepo)
+	service := bmi.NewServices(mockRepo, nil)
 
 	createdAt1 := time.Now()
 	createdAt2 := time.Now().Add(-time.Hour)
@@ -170,7 +170,7 @@ func TestGetAllBMI(t *testing.T) {
 	mockRepo.On("GetAll", mock.Anything).Return(expectedBMIs, nil)
 
 	ctx := context.Background()
-	result, err := service.GetAllBMI(ctx)
+	result, err := service.GetAllBMI(ctx, domain.BMIOptions{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -186,7 +186,7 @@ func TestGetAllBMI(t *testing.T) {
 
 func TestUpdateBMI(t *testing.T) {
 	mockRepo := new(mocks.MockBMIRepository)
-	service := bmi.NewServices(mockRepo)
+	service := bmi.NewServices(mockRepo, nil)
 
 	bmiToUpdate := &domain.BMI{
 		ID:     1,
@@ -198,6 +198,7 @@ func TestUpdateBMI(t *testing.T) {
 	expectedValue := 23.51
 	bmiToUpdate.Value = expectedValue
 
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.BMI{ID: 1, CreatedAt: time.Now()}, nil)
 	mockRepo.On("Update", mock.Anything, bmiToUpdate).Return(nil)
 
 	ctx := context.Background()
@@ -210,10 +211,10 @@ func TestUpdateBMI(t *testing.T) {
 
 func TestDeleteBMI(t *testing.T) {
 	mockRepo := new(mocks.MockBMIRepository)
-	service := bmi.NewServices(mockRepo)
+	service := bmi.NewServices(mockRepo, nil)
 
 	idToDelete := int64(1)
-	mockRepo.On("Delete", mock.Anything, idToDelete).Return(nil)
+	mockRepo.On("Delete", mock.Anything, idToDelete, mock.AnythingOfType("time.Time")).Return(nil)
 
 	ctx := context.Background()
 	err := service.DeleteBMI(ctx, idToDelete)
@@ -221,3 +222,61 @@ func TestDeleteBMI(t *testing.T) {
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetBMIByIDWithScheme(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.BMI{ID: 1, Value: 24.22}, nil).Once()
+
+	ctx := context.Background()
+	result, err := service.GetBMIByID(ctx, 1, domain.BMIOptions{Scheme: bmi.SchemeWHO, Languages: []string{"en"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "normal", result.CategoryCode)
+	assert.Equal(t, "Normal weight", result.Category)
+	assert.Equal(t, "Average", result.Risk)
+	assert.Equal(t, bmi.SchemeWHO, result.Scheme)
+
+	_, err = service.GetBMIByID(ctx, 1, domain.BMIOptions{Scheme: "unknown"})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAllBMILanguages(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	custom := bmi.Scheme{Name: "custom", Bands: []bmi.Band{
+		{Min: 0, Max: 25, Category: "normal", Risk: "average"},
+		{Min: 25, Category: "heavy", Risk: "increased"},
+	}, Messages: bmi.Messages{"fr": {"normal": "Normal", "heavy": "Lourd"}}}
+	classifiers, err := bmi.NewClassifiers(bmi.DefaultScheme, custom)
+	assert.NoError(t, err)
+	service := bmi.NewServices(mockRepo, nil, bmi.WithClassifiers(classifiers))
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.BMI{{ID: 1, Value: 24.22}}, nil)
+
+	tests := []struct {
+		name     string
+		opts     domain.BMIOptions
+		language string
+		category string
+		risk     string
+		riskCode string
+	}{
+		{"default language", domain.BMIOptions{}, "th", "ท้วม / โรคอ้วนระดับ 1", "อันตรายระดับ 1", "increased"},
+		{"english", domain.BMIOptions{Languages: []string{"de", "en", "th"}}, "en", "Overweight", "Increased", "increased"},
+		{"fallback", domain.BMIOptions{Languages: []string{"de"}}, "th", "ท้วม / โรคอ้วนระดับ 1", "อันตรายระดับ 1", "increased"},
+		{"scheme messages", domain.BMIOptions{Scheme: "custom", Languages: []string{"fr"}}, "fr", "Normal", "เท่าคนปกติ", "average"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.GetAllBMI(context.Background(), tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.language, result[0].Language)
+			assert.Equal(t, tt.category, result[0].Category)
+			assert.Equal(t, tt.risk, result[0].Risk)
+			assert.Equal(t, tt.riskCode, result[0].RiskCode)
+		})
+	}
+}
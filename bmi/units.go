@@ -0,0 +1,117 @@
+package bmi
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// The sizes of the units in meters and kilograms
+const (
+	metersPerCentimeter = 0.01
+	metersPerInch       = 0.0254
+	metersPerFoot       = 0.3048
+	kilogramsPerPound   = 0.45359237
+	kilogramsPerStone   = 6.35029318
+
+	inchesPerFoot = 12
+)
+
+// The measurements beyond these are typing mistakes, such as centimeters sent as meters. They're
+// wide enough for the extreme cases on record, of the children as of the adults
+const (
+	minHeight = 0.5  // m
+	maxHeight = 2.75 // m
+	minWeight = 2.0  // kg
+	maxWeight = 650  // kg
+	minValue  = 5.0
+	maxValue  = 250
+)
+
+// measure converts the height to meters and the weight to kilograms, in feet the inches add up
+// to the height. The measurements must be plausible ones
+func measure(height, inches float64, heightUnit string, weight float64, weightUnit string) (float64, float64, error) {
+	if inches != 0 && heightUnit != domain.UnitFoot {
+		return 0, 0, fmt.Errorf("%w: the inches only add up to a height in feet", domain.ErrBadParamInput)
+	}
+	if height < 0 || inches < 0 {
+		return 0, 0, fmt.Errorf("%w: the height can't be negative", domain.ErrBadParamInput)
+	}
+
+	switch heightUnit {
+	case "", domain.UnitMeter:
+	case domain.UnitCentimeter:
+		height *= metersPerCentimeter
+	case domain.UnitInch:
+		height *= metersPerInch
+	case domain.UnitFoot:
+		height = height*metersPerFoot + inches*metersPerInch
+	default:
+		return 0, 0, fmt.Errorf("%w: unknown height unit %q, use m, cm, in or ft", domain.ErrBadParamInput, heightUnit)
+	}
+	switch weightUnit {
+	case "", domain.UnitKilogram:
+	case domain.UnitPound:
+		weight *= kilogramsPerPound
+	case domain.UnitStone:
+		weight *= kilogramsPerStone
+	default:
+		return 0, 0, fmt.Errorf("%w: unknown weight unit %q, use kg, lb or st", domain.ErrBadParamInput, weightUnit)
+	}
+
+	if height < minHeight || height > maxHeight {
+		return 0, 0, fmt.Errorf("%w: a height of %.2f m is not plausible, it must be between %v and %v m",
+			domain.ErrBadParamInput, height, minHeight, maxHeight)
+	}
+	if weight < minWeight || weight > maxWeight {
+		return 0, 0, fmt.Errorf("%w: a weight of %.1f kg is not plausible, it must be between %v and %v kg",
+			domain.ErrBadParamInput, weight, minWeight, maxWeight)
+	}
+	if value := weight / (height * height); value < minValue || value > maxValue {
+		return 0, 0, fmt.Errorf("%w: a BMI of %.1f is not plausible, check the height and the weight",
+			domain.ErrBadParamInput, value)
+	}
+	return height, weight, nil
+}
+
+// checkUnits makes sure the unit system is known, metric when empty
+func checkUnits(units string) error {
+	switch units {
+	case "", domain.UnitsMetric, domain.UnitsImperial:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown unit system %q, use metric or imperial", domain.ErrBadParamInput, units)
+}
+
+// convert turns the record's height in meters and weights in kilograms into the unit system,
+// feet and inches then pounds in the imperial one, the waist in inches
+func convert(bmi *domain.BMI, units string) {
+	if units != domain.UnitsImperial {
+		bmi.HeightUnit, bmi.WeightUnit = domain.UnitMeter, domain.UnitKilogram
+		if bmi.Waist > 0 {
+			bmi.WaistUnit = domain.UnitMeter
+		}
+		return
+	}
+	inches := bmi.Height / metersPerInch
+	feet := math.Floor(inches / inchesPerFoot)
+	bmi.Height, bmi.HeightInches, bmi.HeightUnit = feet, inches-feet*inchesPerFoot, domain.UnitFoot
+	bmi.Weight, bmi.WeightUnit = bmi.Weight/kilogramsPerPound, domain.UnitPound
+	if bmi.Waist > 0 {
+		bmi.Waist, bmi.WaistUnit = bmi.Waist/metersPerInch, domain.UnitInch
+	}
+	if bmi.Metrics != nil {
+		bmi.Metrics.HealthyWeightMin = toPounds(bmi.Metrics.HealthyWeightMin)
+		bmi.Metrics.HealthyWeightMax = toPounds(bmi.Metrics.HealthyWeightMax)
+	}
+}
+
+// toPounds converts the weight in kilograms, if any
+func toPounds(weight *float64) *float64 {
+	if weight == nil {
+		return nil
+	}
+	pounds := *weight / kilogramsPerPound
+	return &pounds
+}
@@ -0,0 +1,116 @@
+package bmi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/bmi"
+	"github.com/bxcodec/go-clean-arch/bmi/mocks"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestCalculateAndStoreBMIUnits(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        domain.BMICalculationRequest
+		height     float64
+		weight     float64
+		heightUnit string
+		weightUnit string
+	}{
+		{"metric", domain.BMICalculationRequest{Height: 1.75, Weight: 70}, 1.75, 70, "m", "kg"},
+		{"centimeters", domain.BMICalculationRequest{Height: 175, HeightUnit: "cm", Weight: 70, WeightUnit: "kg"}, 1.75, 70, "cm", "kg"},
+		{"feet and inches", domain.BMICalculationRequest{Height: 5, HeightInches: 9, HeightUnit: "ft", Weight: 154, WeightUnit: "lb"}, 1.7526, 69.853, "ft", "lb"},
+		{"inches and stones", domain.BMICalculationRequest{Height: 69, HeightUnit: "in", Weight: 11, WeightUnit: "st"}, 1.7526, 69.853, "in", "st"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+			mockRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.BMI")).Return(nil).Run(func(args mock.Arguments) {
+				stored := args.Get(1).(*domain.BMI)
+				assert.InDelta(t, tt.height, stored.Height, 0.001)
+				assert.InDelta(t, tt.weight, stored.Weight, 0.001)
+				assert.Equal(t, tt.heightUnit, stored.EnteredHeightUnit)
+				assert.Equal(t, tt.weightUnit, stored.EnteredWeightUnit)
+			}).Once()
+
+			result, err := service.CalculateAndStoreBMI(context.Background(), tt.req, domain.BMIOptions{})
+			require.NoError(t, err)
+			assert.InDelta(t, tt.weight/(tt.height*tt.height), result.Value, 0.01)
+			assert.Equal(t, "m", result.HeightUnit)
+			assert.Equal(t, "kg", result.WeightUnit)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalculateAndStoreBMIImplausible(t *testing.T) {
+	tests := []struct {
+		name string
+		req  domain.BMICalculationRequest
+	}{
+		{"centimeters as meters", domain.BMICalculationRequest{Height: 175, Weight: 70}},
+		{"grams", domain.BMICalculationRequest{Height: 1.75, Weight: 70000}},
+		{"too light for the height", domain.BMICalculationRequest{Height: 2.5, Weight: 20}},
+		{"unknown unit", domain.BMICalculationRequest{Height: 1.75, HeightUnit: "yd", Weight: 70}},
+		{"inches without feet", domain.BMICalculationRequest{Height: 1.75, HeightInches: 3, Weight: 70}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBMIRepository)
+			service := bmi.NewServices(mockRepo, nil)
+
+			_, err := service.CalculateAndStoreBMI(context.Background(), tt.req, domain.BMIOptions{})
+			assert.ErrorIs(t, err, domain.ErrBadParamInput)
+			mockRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetBMIByIDImperial(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.BMI{ID: 1, Height: 1.7526, Weight: 69.853, Value: 22.74, EnteredHeightUnit: "cm"}, nil).Once()
+
+	result, err := service.GetBMIByID(context.Background(), 1, domain.BMIOptions{Units: domain.UnitsImperial})
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, result.Height)
+	assert.InDelta(t, 9, result.HeightInches, 0.001)
+	assert.Equal(t, "ft", result.HeightUnit)
+	assert.InDelta(t, 154, result.Weight, 0.01)
+	assert.Equal(t, "lb", result.WeightUnit)
+	assert.Equal(t, "cm", result.EnteredHeightUnit)
+
+	_, err = service.GetBMIByID(context.Background(), 1, domain.BMIOptions{Units: "nautical"})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateBMIUnits(t *testing.T) {
+	mockRepo := new(mocks.MockBMIRepository)
+	service := bmi.NewServices(mockRepo, nil)
+
+	record := &domain.BMI{ID: 1, Height: 175, HeightUnit: "cm", Weight: 154, WeightUnit: "lb"}
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.BMI{ID: 1, CreatedAt: time.Now()}, nil).Once()
+	mockRepo.On("Update", mock.Anything, record).Return(nil).Once()
+
+	err := service.UpdateBMI(context.Background(), record)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.75, record.Height, 0.001)
+	assert.InDelta(t, 69.853, record.Weight, 0.001)
+	assert.InDelta(t, 22.81, record.Value, 0.01)
+	assert.Equal(t, "cm", record.EnteredHeightUnit)
+	assert.Equal(t, "lb", record.EnteredWeightUnit)
+
+	err = service.UpdateBMI(context.Background(), &domain.BMI{ID: 1, Height: 175, Weight: 70})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,184 @@
+package comment
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// DefaultEditWindow is how long after posting a comment can be edited, unless WithEditWindow says otherwise
+const DefaultEditWindow = 15 * time.Minute
+
+// editTokenSize is how many random bytes the edit token of a comment is made of
+const editTokenSize = 32
+
+// CommentRepository represent the comment's repository contract
+//
+//go:generate mockery --name CommentRepository
+type CommentRepository interface {
+	Fetch(ctx context.Context, q domain.CommentQuery) ([]domain.Comment, domain.Page, error)
+	GetByID(ctx context.Context, id int64) (domain.Comment, error)
+	Store(ctx context.Context, c *domain.Comment) error
+	Update(ctx context.Context, c *domain.Comment) error
+}
+
+// ArticleRepository represent the article's repository contract
+//
+//go:generate mockery --name ArticleRepository
+type ArticleRepository interface {
+	GetByID(ctx context.Context, id int64) (domain.Article, error)
+}
+
+type Service struct {
+	commentRepo CommentRepository
+	articleRepo ArticleRepository
+
+	editWindow time.Duration
+}
+
+// Option configures the optional behaviour of the comment service
+type Option func(*Service)
+
+// WithEditWindow sets how long after posting a comment can be edited
+func WithEditWindow(d time.Duration) Option {
+	return func(s *Service) {
+		s.editWindow = d
+	}
+}
+
+// NewService will create a new comment service object
+func NewService(c CommentRepository, ar ArticleRepository, opts ...Option) *Service {
+	s := &Service{
+		commentRepo: c,
+		articleRepo: ar,
+		editWindow:  DefaultEditWindow,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Fetch will fetch a page of the comments matching the query. When it's narrowed down
+// to an article, the article must be published
+func (s *Service) Fetch(ctx context.Context, q domain.CommentQuery) ([]domain.Comment, domain.Page, error) {
+	if q.ArticleID != 0 {
+		err := s.checkArticle(ctx, q.ArticleID)
+		if err != nil {
+			return nil, domain.Page{}, err
+		}
+	}
+	return s.commentRepo.Fetch(ctx, q)
+}
+
+// Store will post the comment, it's held for moderation until approved. A reply must be
+// to an approved comment of the same article. c.EditToken is the secret needed to edit it later
+func (s *Service) Store(ctx context.Context, c *domain.Comment) (err error) {
+	err = s.checkArticle(ctx, c.ArticleID)
+	if err != nil {
+		return
+	}
+
+	if c.ParentID != 0 {
+		parent, err := s.commentRepo.GetByID(ctx, c.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent.ArticleID != c.ArticleID || parent.Status != domain.CommentApproved {
+			return domain.ErrNotFound
+		}
+	}
+
+	token := make([]byte, editTokenSize)
+	_, err = rand.Read(token)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	c.Status = domain.CommentPending
+	c.ReplyCount = 0
+	c.EditToken = hex.EncodeToString(token)
+	c.EditTokenHash = hashEditToken(c.EditToken)
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	return s.commentRepo.Store(ctx, c)
+}
+
+// Update will replace the content of the comment while its edit window is open, c.EditToken must be
+// the one handed out when it was posted. The edited comment goes back to moderation so an approved
+// comment can't be turned into something else
+func (s *Service) Update(ctx context.Context, c *domain.Comment) (err error) {
+	existed, err := s.commentRepo.GetByID(ctx, c.ID)
+	if err != nil {
+		return
+	}
+	if existed.ArticleID != c.ArticleID {
+		return domain.ErrNotFound
+	}
+	// the comments posted before the edit tokens have none and can't be edited
+	if existed.EditTokenHash == "" ||
+		subtle.ConstantTimeCompare([]byte(hashEditToken(c.EditToken)), []byte(existed.EditTokenHash)) != 1 {
+		return domain.ErrForbidden
+	}
+
+	now := time.Now()
+	if now.After(existed.CreatedAt.Add(s.editWindow)) {
+		return domain.ErrEditWindowClosed
+	}
+
+	existed.Content = c.Content
+	existed.Status = domain.CommentPending
+	existed.UpdatedAt = now
+	err = s.commentRepo.Update(ctx, &existed)
+	if err != nil {
+		return
+	}
+	*c = existed
+	return
+}
+
+// Moderate will move the comment to the given moderation status
+func (s *Service) Moderate(ctx context.Context, id int64, status domain.CommentStatus) (res domain.Comment, err error) {
+	if !status.IsValid() {
+		return domain.Comment{}, domain.ErrBadParamInput
+	}
+
+	res, err = s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.Status == status {
+		return
+	}
+
+	res.Status = status
+	err = s.commentRepo.Update(ctx, &res)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return
+}
+
+// checkArticle makes sure the article exists and is published, comments are hidden along with their article
+func (s *Service) checkArticle(ctx context.Context, articleID int64) error {
+	ar, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if ar.Status != domain.StatusPublished {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// hashEditToken is how the edit token of a comment is stored
+func hashEditToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
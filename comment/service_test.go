@@ -0,0 +1,207 @@
+package comment_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/comment"
+	"github.com/bxcodec/go-clean-arch/comment/mocks"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+var publishedArticle = domain.Article{ID: 12, Status: domain.StatusPublished}
+
+func TestFetch(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		q := domain.CommentQuery{ArticleID: 12, Num: 10, Status: domain.CommentApproved}
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(publishedArticle, nil).Once()
+		mockCommentRepo.On("Fetch", mock.Anything, q).Return([]domain.Comment{{ID: 1}}, domain.Page{Next: "next"}, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		list, page, err := u.Fetch(context.TODO(), q)
+
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, "next", page.Next)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("article-is-draft", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(13)).
+			Return(domain.Article{ID: 13, Status: domain.StatusDraft}, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		_, _, err := u.Fetch(context.TODO(), domain.CommentQuery{ArticleID: 13})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(publishedArticle, nil).Once()
+		mockCommentRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		c := domain.Comment{ArticleID: 12, AuthorName: "Iman", Content: "Nice", Status: domain.CommentApproved}
+		err := u.Store(context.TODO(), &c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentPending, c.Status)
+		assert.False(t, c.CreatedAt.IsZero())
+		// only the hash of the edit token is stored
+		assert.Len(t, c.EditToken, 64)
+		assert.Equal(t, hashToken(c.EditToken), c.EditTokenHash)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("reply", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(publishedArticle, nil).Once()
+		mockCommentRepo.On("GetByID", mock.Anything, int64(3)).
+			Return(domain.Comment{ID: 3, ArticleID: 12, Status: domain.CommentApproved}, nil).Once()
+		mockCommentRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		err := u.Store(context.TODO(), &domain.Comment{ArticleID: 12, ParentID: 3, AuthorName: "Iman", Content: "Agreed"})
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("reply-to-pending-comment", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(publishedArticle, nil).Once()
+		mockCommentRepo.On("GetByID", mock.Anything, int64(4)).
+			Return(domain.Comment{ID: 4, ArticleID: 12, Status: domain.CommentPending}, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		err := u.Store(context.TODO(), &domain.Comment{ArticleID: 12, ParentID: 4, AuthorName: "Iman", Content: "Agreed"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("reply-to-another-article", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(12)).Return(publishedArticle, nil).Once()
+		mockCommentRepo.On("GetByID", mock.Anything, int64(5)).
+			Return(domain.Comment{ID: 5, ArticleID: 99, Status: domain.CommentApproved}, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		err := u.Store(context.TODO(), &domain.Comment{ArticleID: 12, ParentID: 5, AuthorName: "Iman", Content: "Agreed"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockArticleRepo.AssertExpectations(t)
+		mockCommentRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		existed := domain.Comment{ID: 7, ArticleID: 12, AuthorName: "Iman", Content: "Nice",
+			Status: domain.CommentApproved, EditTokenHash: hashToken("secret"), CreatedAt: time.Now().Add(-time.Minute)}
+		mockCommentRepo.On("GetByID", mock.Anything, int64(7)).Return(existed, nil).Once()
+		mockCommentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		c := domain.Comment{ID: 7, ArticleID: 12, Content: "Nicer", EditToken: "secret"}
+		err := u.Update(context.TODO(), &c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Nicer", c.Content)
+		assert.Equal(t, "Iman", c.AuthorName)
+		assert.Equal(t, domain.CommentPending, c.Status)
+		assert.Empty(t, c.EditToken)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("edit-window-closed", func(t *testing.T) {
+		existed := domain.Comment{ID: 8, ArticleID: 12, EditTokenHash: hashToken("secret"), CreatedAt: time.Now().Add(-time.Hour)}
+		mockCommentRepo.On("GetByID", mock.Anything, int64(8)).Return(existed, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo, comment.WithEditWindow(30*time.Minute))
+		err := u.Update(context.TODO(), &domain.Comment{ID: 8, ArticleID: 12, Content: "Nicer", EditToken: "secret"})
+
+		assert.ErrorIs(t, err, domain.ErrEditWindowClosed)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("wrong-edit-token", func(t *testing.T) {
+		for _, token := range []string{"", "guess"} {
+			existed := domain.Comment{ID: 10, ArticleID: 12, EditTokenHash: hashToken("secret"), CreatedAt: time.Now()}
+			mockCommentRepo.On("GetByID", mock.Anything, int64(10)).Return(existed, nil).Once()
+
+			u := comment.NewService(mockCommentRepo, mockArticleRepo)
+			err := u.Update(context.TODO(), &domain.Comment{ID: 10, ArticleID: 12, Content: "Spam", EditToken: token})
+
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+		}
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("comment-without-edit-token", func(t *testing.T) {
+		existed := domain.Comment{ID: 11, ArticleID: 12, CreatedAt: time.Now()}
+		mockCommentRepo.On("GetByID", mock.Anything, int64(11)).Return(existed, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		err := u.Update(context.TODO(), &domain.Comment{ID: 11, ArticleID: 12, Content: "Spam"})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("comment-of-another-article", func(t *testing.T) {
+		existed := domain.Comment{ID: 9, ArticleID: 99, CreatedAt: time.Now()}
+		mockCommentRepo.On("GetByID", mock.Anything, int64(9)).Return(existed, nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		err := u.Update(context.TODO(), &domain.Comment{ID: 9, ArticleID: 12, Content: "Nicer"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockCommentRepo.AssertExpectations(t)
+	})
+}
+
+func TestModerate(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentRepository)
+	mockArticleRepo := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+		mockCommentRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(domain.Comment{ID: 7, Status: domain.CommentPending, UpdatedAt: updatedAt}, nil).Once()
+		mockCommentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		c, err := u.Moderate(context.TODO(), 7, domain.CommentApproved)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentApproved, c.Status)
+		assert.Equal(t, updatedAt, c.UpdatedAt)
+		mockCommentRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-status", func(t *testing.T) {
+		u := comment.NewService(mockCommentRepo, mockArticleRepo)
+		_, err := u.Moderate(context.TODO(), 7, domain.CommentStatus("spam"))
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockCommentRepo.AssertExpectations(t)
+	})
+}
+
+// hashToken hashes the edit token the way the service stores it
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
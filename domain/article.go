@@ -6,10 +6,103 @@ import (
 
 // Article is representing the Article data struct
 type Article struct {
-	ID        int64     `json:"id"`
-	Title     string    `json:"title" validate:"required"`
-	Content   string    `json:"content" validate:"required"`
-	Author    Author    `json:"author"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int64         `json:"id"`
+	Title      string        `json:"title" validate:"required"`
+	Slug       string        `json:"slug"` // derived from the title, the former slugs keep redirecting to the article
+	Content    string        `json:"content,omitempty" validate:"required"`
+	Author     Author        `json:"author"`
+	Categories []Category    `json:"categories,omitempty"`
+	Tags       []string      `json:"tags,omitempty"`
+	Status     ArticleStatus `json:"status"`
+	// PublishAt is when the article goes public once scheduled, or when it went public once published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	// Version goes up by one on every change of the article, the writes check it hasn't moved since it was read
+	Version int64 `json:"version"`
+	// DeletedAt is only set on the articles in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// WordCount, ReadingMinutes and Excerpt are derived from the HTML content whenever it's saved,
+	// the excerpt is plain text. The summary listings carry the excerpt without the content
+	WordCount      int    `json:"word_count"`
+	ReadingMinutes int    `json:"reading_minutes"`
+	Excerpt        string `json:"excerpt"`
+	// TitleScope is what the title is unique within: 0 for every article, or the author's id
+	// when the authors may share titles. It's set by the service and only read by the storage
+	TitleScope int64 `json:"-"`
+}
+
+// ArticleQuery holds the filters and paging parameters of an article listing
+type ArticleQuery struct {
+	Cursor string
+	Num    int64
+	// Order and Sort may be left empty once paging, the cursor remembers them
+	Order SortOrder
+	Sort  ArticleSort
+	// Status keeps the articles in the given status only, every status is listed when empty
+	Status ArticleStatus
+	// Tags keeps the articles carrying every given tag only
+	Tags []string
+	// AuthorID and CategoryID keep the articles of the given author or category only, when set
+	AuthorID   int64
+	CategoryID int64
+	// TitlePrefix keeps the articles whose title starts with it, whatever the case
+	TitlePrefix string
+	// The date ranges include their From and exclude their To, a nil bound leaves the range open
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+}
+
+// ArticleSort is the field an article listing is sorted by, the articles are sorted by creation by default
+type ArticleSort string
+
+const (
+	SortByCreatedAt ArticleSort = "created_at"
+	SortByUpdatedAt ArticleSort = "updated_at"
+	SortByTitle     ArticleSort = "title"
+	// SortByPublishAt sorts the articles by when they went public, the unpublished ones by their creation
+	SortByPublishAt ArticleSort = "publish_at"
+)
+
+// IsValid reports whether the articles can be sorted by the field
+func (s ArticleSort) IsValid() bool {
+	return s == SortByCreatedAt || s == SortByUpdatedAt || s == SortByTitle || s == SortByPublishAt
+}
+
+// ArticleStatus is the step of the editorial workflow an article is at
+type ArticleStatus string
+
+const (
+	StatusDraft     ArticleStatus = "draft"
+	StatusInReview  ArticleStatus = "in_review"
+	StatusScheduled ArticleStatus = "scheduled"
+	StatusPublished ArticleStatus = "published"
+	StatusArchived  ArticleStatus = "archived"
+)
+
+// articleTransitions lists the statuses each status can move to
+var articleTransitions = map[ArticleStatus][]ArticleStatus{
+	StatusDraft:     {StatusInReview, StatusArchived},
+	StatusInReview:  {StatusDraft, StatusScheduled, StatusPublished},
+	StatusScheduled: {StatusDraft, StatusPublished},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+// IsValid reports whether the status is one of the workflow's
+func (s ArticleStatus) IsValid() bool {
+	_, ok := articleTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an article in this status may move to the given one
+func (s ArticleStatus) CanTransitionTo(to ArticleStatus) bool {
+	for _, next := range articleTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
 }
@@ -10,11 +10,112 @@ type BMI struct {
 	Category  string    `json:"category,omitempty"`
 	Risk      string    `json:"risk,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
+	// Scheme is the classification scheme the category and the risk come from
+	Scheme string `json:"scheme,omitempty"`
+	// CategoryCode and RiskCode are stable codes, Category and Risk are their labels in Language
+	CategoryCode string `json:"category_code,omitempty"`
+	RiskCode     string `json:"risk_code,omitempty"`
+	Language     string `json:"language,omitempty"`
+	// HeightUnit and WeightUnit are the units of Height and Weight, in feet HeightInches adds up to the height
+	HeightUnit   string  `json:"height_unit,omitempty"`
+	HeightInches float64 `json:"height_inches,omitempty"`
+	WeightUnit   string  `json:"weight_unit,omitempty"`
+	// EnteredHeightUnit and EnteredWeightUnit are the units the measurements were entered in
+	EnteredHeightUnit string `json:"entered_height_unit,omitempty"`
+	EnteredWeightUnit string `json:"entered_weight_unit,omitempty"`
+	// BirthDate (YYYY-MM-DD) and Sex make the BMI of a child assessed for their age, as a
+	// Percentile and a ZScore of the growth reference
+	BirthDate  string   `json:"birth_date,omitempty"`
+	Sex        string   `json:"sex,omitempty"`
+	Percentile *float64 `json:"percentile,omitempty"`
+	ZScore     *float64 `json:"z_score,omitempty"`
+	// Waist is the waist circumference in WaistUnit and ActivityLevel how active the person is,
+	// both optional. The Metrics are estimated at the measurement from what the record holds
+	Waist         float64      `json:"waist,omitempty"`
+	WaistUnit     string       `json:"waist_unit,omitempty"`
+	ActivityLevel string       `json:"activity_level,omitempty"`
+	Metrics       *BodyMetrics `json:"metrics,omitempty"`
+	// DeletedAt is only set on the records in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type BMICalculationRequest struct {
 	Height float64 `json:"height" validate:"required,gt=0"`
 	Weight float64 `json:"weight" validate:"required,gt=0"`
+	// HeightUnit is meters when empty, in feet HeightInches adds up to the height as in 5 ft 9 in
+	HeightUnit   string  `json:"height_unit" validate:"omitempty,oneof=m cm in ft"`
+	HeightInches float64 `json:"height_inches" validate:"gte=0"`
+	// WeightUnit is kilograms when empty
+	WeightUnit string `json:"weight_unit" validate:"omitempty,oneof=kg lb st"`
+	// BirthDate (YYYY-MM-DD) and Sex are optional, the BMI of the children from 2 to 19 years old
+	// is assessed for their age and needs both
+	BirthDate string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	Sex       string `json:"sex" validate:"omitempty,oneof=male female"`
+	// Waist is the optional waist circumference, in meters when WaistUnit is empty
+	Waist     float64 `json:"waist" validate:"gte=0"`
+	WaistUnit string  `json:"waist_unit" validate:"omitempty,oneof=m cm in"`
+	// ActivityLevel is optional, the daily energy expenditure needs it
+	ActivityLevel string `json:"activity_level" validate:"omitempty,oneof=sedentary light moderate active very_active"`
+}
+
+// BodyMetrics are estimates of the body composition derived from the measurements, each is
+// missing when the record lacks what it needs
+type BodyMetrics struct {
+	// BMRMifflinStJeor and BMRHarrisBenedict are the basal metabolic rate in kcal a day by both
+	// equations, of the adults whose age and sex are known
+	BMRMifflinStJeor  *float64 `json:"bmr_mifflin_st_jeor,omitempty"`
+	BMRHarrisBenedict *float64 `json:"bmr_harris_benedict,omitempty"`
+	// TDEE is the total daily energy expenditure in kcal at the activity level, from the Mifflin-St Jeor BMR
+	TDEE *float64 `json:"tdee,omitempty"`
+	// BodyFat is the body fat percentage estimated from the BMI, the age and the sex
+	BodyFat       *float64 `json:"body_fat_percentage,omitempty"`
+	WaistToHeight *float64 `json:"waist_to_height_ratio,omitempty"`
+	// HealthyWeightMin and HealthyWeightMax bound the weights of a normal BMI at the height, in
+	// the record's WeightUnit
+	HealthyWeightMin *float64 `json:"healthy_weight_min,omitempty"`
+	HealthyWeightMax *float64 `json:"healthy_weight_max,omitempty"`
+}
+
+// The activity levels of the daily energy expenditure
+const (
+	ActivitySedentary  = "sedentary"
+	ActivityLight      = "light"
+	ActivityModerate   = "moderate"
+	ActivityActive     = "active"
+	ActivityVeryActive = "very_active"
+)
+
+// The sexes of the growth references
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+)
+
+// The units of the BMI measurements
+const (
+	UnitMeter      = "m"
+	UnitCentimeter = "cm"
+	UnitInch       = "in"
+	UnitFoot       = "ft"
+	UnitKilogram   = "kg"
+	UnitPound      = "lb"
+	UnitStone      = "st"
+)
+
+// The unit systems the BMI records are presented in
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+)
+
+// BMIOptions tell how the BMI records are presented
+type BMIOptions struct {
+	// Scheme is the classification scheme of the records, the default one when empty
+	Scheme string
+	// Languages are the languages to label the records in, in order of preference
+	Languages []string
+	// Units is the unit system of the measurements, metric when empty
+	Units string
 }
 
 type PointStruct struct {
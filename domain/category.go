@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// Category representing the Category data struct
+type Category struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tag       string    `json:"tag"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Comment is a reader's response to an article, or to another comment of the article
+type Comment struct {
+	ID        int64 `json:"id"`
+	ArticleID int64 `json:"article_id"`
+	// ParentID is the comment this one replies to, 0 for a top-level comment
+	ParentID   int64         `json:"parent_id,omitempty"`
+	AuthorName string        `json:"author_name" validate:"required,max=100"`
+	Content    string        `json:"content" validate:"required"`
+	Status     CommentStatus `json:"status"`
+	// ReplyCount is how many approved replies the comment has
+	ReplyCount int64     `json:"reply_count"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	// EditToken is the secret the author edits the comment with, it's only handed out once the comment is posted
+	EditToken string `json:"edit_token,omitempty"`
+	// EditTokenHash is the SHA-256 of the edit token in hex, the token itself is never stored
+	EditTokenHash string `json:"-"`
+}
+
+// CommentQuery holds the filters and paging parameters of a comment listing
+type CommentQuery struct {
+	Cursor string
+	Num    int64
+	// Order may be left empty once paging, the cursor remembers it
+	Order SortOrder
+	// ArticleID keeps the comments of the given article only, 0 lists the comments of every article
+	ArticleID int64
+	// ParentID keeps the replies to the given comment only, 0 the top-level comments,
+	// the comments at every depth are listed when nil
+	ParentID *int64
+	// Status keeps the comments in the given status only, every status is listed when empty
+	Status CommentStatus
+}
+
+// CommentStatus is where a comment stands in moderation
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"
+	CommentApproved CommentStatus = "approved"
+	CommentRejected CommentStatus = "rejected"
+)
+
+// IsValid reports whether the status is one of the moderation's
+func (s CommentStatus) IsValid() bool {
+	return s == CommentPending || s == CommentApproved || s == CommentRejected
+}
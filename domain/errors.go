@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInternalServerError will throw if any the Internal Server Error happen
@@ -9,6 +12,18 @@ var (
 	ErrNotFound = errors.New("your requested Item is not found")
 	// ErrConflict will throw if the current action already exists
 	ErrConflict = errors.New("your Item already exist")
+	// ErrTitleTaken will throw if another item already has the title, it's an ErrConflict too
+	ErrTitleTaken = fmt.Errorf("%w: the title is taken", ErrConflict)
 	// ErrBadParamInput will throw if the given request-body or params is not valid
 	ErrBadParamInput = errors.New("given Param is not valid")
+	// ErrPreconditionFailed will throw if the item was modified since the client last read it
+	ErrPreconditionFailed = errors.New("your Item has been modified by someone else")
+	// ErrInvalidTransition will throw if the item can't move to the requested status from its current one
+	ErrInvalidTransition = errors.New("your Item can't move to the requested status")
+	// ErrEditWindowClosed will throw if the item can no longer be edited
+	ErrEditWindowClosed = errors.New("your Item can no longer be edited")
+	// ErrForbidden will throw if the caller isn't allowed to change the item
+	ErrForbidden = errors.New("you are not allowed to change this Item")
+	// ErrPartialResult will throw alongside a result that misses some of its details
+	ErrPartialResult = errors.New("some details of your Item could not be loaded")
 )
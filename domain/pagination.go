@@ -0,0 +1,21 @@
+package domain
+
+// SortOrder is the direction a listing is sorted in
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// IsValid reports whether the order is one of the supported ones
+func (o SortOrder) IsValid() bool {
+	return o == SortAsc || o == SortDesc
+}
+
+// Page holds the opaque cursors around a page of results,
+// Next is empty on the last page and Prev on the first one
+type Page struct {
+	Next string
+	Prev string
+}
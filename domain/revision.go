@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Revision is an immutable snapshot of an article, one is written every time the article is saved
+type Revision struct {
+	ID        int64     `json:"id"`
+	ArticleID int64     `json:"article_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Author    Author    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DiffOp tells what happened to a line between two revisions
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine is a line of a diff
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiff holds the line-level differences from one revision of an article to another
+type RevisionDiff struct {
+	From    int64      `json:"from"`
+	To      int64      `json:"to"`
+	Title   []DiffLine `json:"title"`
+	Content []DiffLine `json:"content"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+// SearchQuery holds the parameters of an article search
+type SearchQuery struct {
+	// Terms are the words looked up in the title and content of the articles
+	Terms  string
+	Cursor string
+	Num    int64
+}
+
+// SearchHit is an article matching a search, the most relevant hits have the highest score
+type SearchHit struct {
+	Article Article `json:"article"`
+	Score   float64 `json:"score"`
+	// Snippet is an HTML escaped excerpt of the content with the matching words wrapped in <mark>,
+	// semantic searches don't have one
+	Snippet string `json:"snippet,omitempty"`
+}
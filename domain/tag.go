@@ -0,0 +1,9 @@
+package domain
+
+// Tag is a free-form label of the articles, unlike the categories they're made up as the articles are tagged
+type Tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Usage is how many published articles carry the tag
+	Usage int64 `json:"usage"`
+}
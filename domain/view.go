@@ -0,0 +1,10 @@
+package domain
+
+// TrendingArticle is an article ranked by how much it's been read lately, the recent views count
+// more than the older ones so the highest scores are the articles read the most right now
+type TrendingArticle struct {
+	Article Article `json:"article"`
+	// Views is how many times the article was read within the window
+	Views int64   `json:"views"`
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,84 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// DefaultDimension is the size of the vectors the hashing embedder produces unless told otherwise
+const DefaultDimension = 256
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// HashingEmbedder embeds texts with the hashing trick: every word and every pair of
+// consecutive words is hashed onto one of the dimensions. It needs neither a model nor the network
+// and always gives the same vector for the same text, texts sharing words end up close to each other
+type HashingEmbedder struct {
+	dimension int
+}
+
+// NewHashingEmbedder will create an embedder producing vectors of the given dimension
+func NewHashingEmbedder(dimension int) *HashingEmbedder {
+	if dimension <= 0 {
+		dimension = DefaultDimension
+	}
+	return &HashingEmbedder{dimension: dimension}
+}
+
+// Dimension is the size of the vectors returned by Embed
+func (e *HashingEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// Embed returns the unit vector of the text, a text without any word can't be embedded
+func (e *HashingEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	words := strings.FieldsFunc(strings.ToLower(htmlTag.ReplaceAllString(text, " ")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: there is no word to embed", domain.ErrBadParamInput)
+	}
+
+	vector := make([]float64, e.dimension)
+	for i, word := range words {
+		e.add(vector, word, 1)
+		if i > 0 {
+			e.add(vector, words[i-1]+" "+word, 0.5)
+		}
+	}
+
+	norm := 0.0
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	// the features may cancel each other out, there is no direction to normalize then
+	if norm == 0 {
+		return nil, fmt.Errorf("%w: the words of the text cancel each other out", domain.ErrBadParamInput)
+	}
+
+	res := make([]float32, e.dimension)
+	for i, v := range vector {
+		res[i] = float32(v / norm)
+	}
+	return res, nil
+}
+
+// add hashes the feature onto a dimension, the sign of the hash spreads the collisions around zero
+func (e *HashingEmbedder) add(vector []float64, feature string, weight float64) {
+	h := fnv.New64a()
+	h.Write([]byte(feature)) //nolint:errcheck // writing to a hash never fails
+	sum := h.Sum64()
+
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(e.dimension)] += weight
+}
@@ -0,0 +1,49 @@
+package embedding_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/embedding"
+)
+
+func cosine(a, b []float32) float64 {
+	dot := 0.0
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestHashingEmbedder(t *testing.T) {
+	e := embedding.NewHashingEmbedder(64)
+	assert.Equal(t, 64, e.Dimension())
+
+	query, err := e.Embed(context.TODO(), "makan ayam goreng")
+	require.NoError(t, err)
+	assert.Len(t, query, 64)
+	assert.InDelta(t, 1, cosine(query, query), 1e-6)
+
+	again, err := e.Embed(context.TODO(), "<p>Makan ayam, goreng!</p>")
+	require.NoError(t, err)
+	assert.Equal(t, query, again)
+
+	near, err := e.Embed(context.TODO(), "resep ayam goreng")
+	require.NoError(t, err)
+	far, err := e.Embed(context.TODO(), "es teh manis")
+	require.NoError(t, err)
+	assert.Greater(t, cosine(query, near), cosine(query, far))
+}
+
+func TestHashingEmbedderNoWord(t *testing.T) {
+	e := embedding.NewHashingEmbedder(64)
+	for _, text := range []string{"", "   ", "<p> !! </p>", "?!.,;:-"} {
+		vector, err := e.Embed(context.TODO(), text)
+		assert.ErrorIs(t, err, domain.ErrBadParamInput, text)
+		assert.Nil(t, vector, text)
+	}
+}
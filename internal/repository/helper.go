@@ -1,30 +1,127 @@
 package repository
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"time"
-)
 
-const (
-	timeFormat = "2006-01-02T15:04:05.999Z07:00" // reduce precision from RFC3339Nano as date format
+	"github.com/bxcodec/go-clean-arch/domain"
 )
 
-// DecodeCursor will decode cursor from user for mysql
-func DecodeCursor(encodedTime string) (time.Time, error) {
-	byt, err := base64.StdEncoding.DecodeString(encodedTime)
-	if err != nil {
-		return time.Time{}, err
+// cursorVersion prefixes every cursor so its format can evolve without misreading old ones
+const cursorVersion = "v1"
+
+// Cursor is the position of a paging request in a list sorted by (created_at, id), by another
+// column and id, or by (score, id) for searches. The id breaks the tie between items with the same value
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt, PublishAt and Title are the position in the lists sorted by them
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+	PublishAt *time.Time       `json:"publish_at,omitempty"`
+	Title     string           `json:"title,omitempty"`
+	Score     float64          `json:"score,omitempty"`
+	ID        int64            `json:"id"`
+	Order     domain.SortOrder `json:"order"`
+	// Sort is the column the list is sorted by, the cursors made before it was recorded are for created_at
+	Sort string `json:"sort,omitempty"`
+	// Backward asks for the page right before the position instead of the one right after it
+	Backward bool `json:"backward,omitempty"`
+	// Filter is the fingerprint of the filters the listing was made with, see FilterFingerprint
+	Filter string `json:"filter,omitempty"`
+}
+
+// FilterFingerprint condenses the filters of a listing, cursors made for one
+// listing are rejected by another
+func FilterFingerprint(filters ...string) string {
+	if len(filters) == 0 {
+		return ""
 	}
+	sum := sha256.Sum256([]byte(strings.Join(filters, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
 
-	timeString := string(byt)
-	t, err := time.Parse(timeFormat, timeString)
+// CursorKey is a secret cursors are signed with
+type CursorKey struct {
+	Secret []byte
+	// NotAfter ends the grace period of a retired key
+	NotAfter time.Time
+}
 
-	return t, err
+func (k CursorKey) id() string {
+	sum := sha256.Sum256(k.Secret)
+	return hex.EncodeToString(sum[:4])
+}
+
+// CursorCodec signs the cursors handed to the clients with HMAC-SHA256,
+// so they can't be forged to scan arbitrary ranges
+type CursorCodec struct {
+	active  CursorKey
+	retired map[string]CursorKey
+	now     func() time.Time
+}
+
+// NewCursorCodec will create a codec signing with the active secret. Cursors signed with
+// a retired key stay valid until its NotAfter, which gives clients a grace period on rotation
+func NewCursorCodec(active []byte, retired ...CursorKey) *CursorCodec {
+	c := &CursorCodec{
+		active:  CursorKey{Secret: active},
+		retired: make(map[string]CursorKey, len(retired)),
+		now:     time.Now,
+	}
+	for _, key := range retired {
+		c.retired[key.id()] = key
+	}
+	return c
 }
 
 // EncodeCursor will encode cursor from mysql to user
-func EncodeCursor(t time.Time) string {
-	timeString := t.Format(timeFormat)
+func (c *CursorCodec) EncodeCursor(cursor Cursor) string {
+	byt, _ := json.Marshal(cursor) //nolint:errcheck // a Cursor always marshals
+
+	payload := base64.RawURLEncoding.EncodeToString(byt)
+	kid := c.active.id()
+	return strings.Join([]string{cursorVersion, kid, payload, sign(c.active.Secret, kid, payload)}, ".")
+}
+
+// DecodeCursor will decode cursor from user for mysql, unknown versions,
+// unknown or expired keys and bad signatures are all reported as domain.ErrBadParamInput
+func (c *CursorCodec) DecodeCursor(encodedCursor string) (Cursor, error) {
+	parts := strings.Split(encodedCursor, ".")
+	if len(parts) != 4 || parts[0] != cursorVersion {
+		return Cursor{}, domain.ErrBadParamInput
+	}
+	kid, payload, signature := parts[1], parts[2], parts[3]
+
+	key, ok := c.active, kid == c.active.id()
+	if !ok {
+		key, ok = c.retired[kid]
+		if !ok || c.now().After(key.NotAfter) {
+			return Cursor{}, domain.ErrBadParamInput
+		}
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(key.Secret, kid, payload))) {
+		return Cursor{}, domain.ErrBadParamInput
+	}
+
+	byt, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Cursor{}, domain.ErrBadParamInput
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(byt, &cursor)
+	if err != nil || !cursor.Order.IsValid() {
+		return Cursor{}, domain.ErrBadParamInput
+	}
+	return cursor, nil
+}
 
-	return base64.StdEncoding.EncodeToString([]byte(timeString))
+func sign(secret []byte, kid, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(cursorVersion + "." + kid + "." + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
 }
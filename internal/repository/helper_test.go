@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	cursor := Cursor{
+		CreatedAt: time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC),
+		ID:        12,
+		Order:     domain.SortDesc,
+		Backward:  true,
+		Filter:    FilterFingerprint("category", "2"),
+	}
+
+	encoded := codec.EncodeCursor(cursor)
+	assert.True(t, strings.HasPrefix(encoded, cursorVersion+"."))
+
+	decoded, err := codec.DecodeCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestCursorTampered(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	encoded := codec.EncodeCursor(Cursor{ID: 12, Order: domain.SortAsc})
+	parts := strings.Split(encoded, ".")
+
+	forged := NewCursorCodec([]byte("guessed")).EncodeCursor(Cursor{ID: 1, Order: domain.SortAsc})
+	tests := map[string]string{
+		"not-a-cursor":    "MjAxNy0wNS0xOFQxMzo1MDoxOVo",
+		"unknown-version": "v0." + strings.Join(parts[1:], "."),
+		"other-payload":   strings.Join([]string{parts[0], parts[1], strings.Split(forged, ".")[2], parts[3]}, "."),
+		"other-secret":    forged,
+	}
+	for name, encoded := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := codec.DecodeCursor(encoded)
+			assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		})
+	}
+}
+
+func TestCursorKeyRotation(t *testing.T) {
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	oldCodec := NewCursorCodec([]byte("old-secret"))
+	encoded := oldCodec.EncodeCursor(Cursor{ID: 12, Order: domain.SortAsc})
+
+	codec := NewCursorCodec([]byte("new-secret"), CursorKey{Secret: []byte("old-secret"), NotAfter: now.Add(time.Hour)})
+
+	codec.now = func() time.Time { return now }
+	decoded, err := codec.DecodeCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), decoded.ID)
+
+	codec.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = codec.DecodeCursor(encoded)
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	// new cursors are signed with the active key only
+	_, err = oldCodec.DecodeCursor(codec.EncodeCursor(Cursor{ID: 12, Order: domain.SortAsc}))
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+)
+
+// snippetWidth is the length in bytes of the excerpts shown with the search hits
+const snippetWidth = 160
+
+// titleWeight is how much more a word found in the title counts than one found in the content
+const titleWeight = 2
+
+// SearchRepository is an in-memory article.SearchRepository, it ranks the articles by how often
+// the words of the search appear in them. It's meant for tests and local runs without MySQL
+type SearchRepository struct {
+	Cursors *repository.CursorCodec
+
+	mu       sync.RWMutex
+	articles map[int64]domain.Article
+}
+
+// NewSearchRepository will create an in-memory search index holding the given articles
+func NewSearchRepository(cursors *repository.CursorCodec, articles ...domain.Article) *SearchRepository {
+	m := &SearchRepository{
+		Cursors:  cursors,
+		articles: make(map[int64]domain.Article, len(articles)),
+	}
+	m.Index(articles...)
+	return m
+}
+
+// Index adds the articles to the index, replacing the ones with the same id
+func (m *SearchRepository) Index(articles ...domain.Article) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ar := range articles { //nolint
+		m.articles[ar.ID] = ar
+	}
+}
+
+// Remove drops the article from the index
+func (m *SearchRepository) Remove(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.articles, id)
+}
+
+// Search ranks the published articles like the MySQL implementation does: by score, then by id
+func (m *SearchRepository) Search(_ context.Context, q domain.SearchQuery) (res []domain.SearchHit, page domain.Page, err error) {
+	filter := repository.SearchFingerprint(q.Terms)
+	var cursor *repository.Cursor
+	if q.Cursor != "" {
+		decoded, err := m.Cursors.DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, domain.Page{}, err
+		}
+		if decoded.Filter != filter {
+			return nil, domain.Page{}, domain.ErrBadParamInput
+		}
+		cursor = &decoded
+	}
+
+	words := repository.SearchTerms(q.Terms)
+
+	m.mu.RLock()
+	res = make([]domain.SearchHit, 0)
+	for _, ar := range m.articles { //nolint
+		if ar.Status != domain.StatusPublished {
+			continue
+		}
+		score := relevance(ar, words)
+		if score == 0 {
+			continue
+		}
+		if cursor != nil && (score > cursor.Score || (score == cursor.Score && ar.ID <= cursor.ID)) {
+			continue
+		}
+		res = append(res, domain.SearchHit{Article: ar, Score: score})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].Article.ID < res[j].Article.ID
+	})
+
+	if int64(len(res)) > q.Num {
+		res = res[:q.Num]
+		last := res[len(res)-1]
+		page.Next = m.Cursors.EncodeCursor(repository.Cursor{Score: last.Score, ID: last.Article.ID, Order: domain.SortDesc, Filter: filter})
+	}
+	for i := range res {
+		res[i].Snippet = repository.Highlight(res[i].Article.Content, q.Terms, snippetWidth)
+	}
+	return
+}
+
+// relevance counts the occurrences of the words in the article, the title weighs more
+func relevance(ar domain.Article, words []string) float64 {
+	title, content := strings.ToLower(ar.Title), strings.ToLower(ar.Content)
+
+	score := 0
+	for _, word := range words {
+		score += titleWeight*strings.Count(title, word) + strings.Count(content, word)
+	}
+	return float64(score)
+}
@@ -0,0 +1,54 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+	"github.com/bxcodec/go-clean-arch/internal/repository/memory"
+)
+
+var cursors = repository.NewCursorCodec([]byte("secret"))
+
+func TestSearch(t *testing.T) {
+	repo := memory.NewSearchRepository(cursors,
+		domain.Article{ID: 1, Title: "Makan Ayam", Content: "ayam goreng", Status: domain.StatusPublished},
+		domain.Article{ID: 2, Title: "Makan Ikan", Content: "ikan bakar", Status: domain.StatusPublished},
+		domain.Article{ID: 3, Title: "Makan Sayur", Content: "sayur asem", Status: domain.StatusPublished},
+		domain.Article{ID: 4, Title: "Minum", Content: "es teh, tanpa ayam", Status: domain.StatusPublished},
+	)
+
+	hits, page, err := repo.Search(context.TODO(), domain.SearchQuery{Terms: "ayam", Num: 1})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, int64(1), hits[0].Article.ID)
+	assert.Equal(t, float64(3), hits[0].Score)
+	assert.Equal(t, "<mark>ayam</mark> goreng", hits[0].Snippet)
+	assert.NotEmpty(t, page.Next)
+
+	hits, page, err = repo.Search(context.TODO(), domain.SearchQuery{Terms: "ayam", Cursor: page.Next, Num: 1})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, int64(4), hits[0].Article.ID)
+	assert.Empty(t, page.Next)
+}
+
+func TestSearchCursorOfAnotherSearch(t *testing.T) {
+	repo := memory.NewSearchRepository(cursors,
+		domain.Article{ID: 1, Title: "Makan Ayam", Content: "ayam goreng", Status: domain.StatusPublished},
+		domain.Article{ID: 2, Title: "Makan Ikan", Content: "ikan bakar", Status: domain.StatusPublished},
+	)
+
+	_, page, err := repo.Search(context.TODO(), domain.SearchQuery{Terms: "makan", Num: 1})
+	require.NoError(t, err)
+
+	_, _, err = repo.Search(context.TODO(), domain.SearchQuery{Terms: "ikan", Cursor: page.Next, Num: 1})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	_, _, err = repo.Search(context.TODO(), domain.SearchQuery{Terms: "makan", Cursor: "not-a-cursor", Num: 1})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
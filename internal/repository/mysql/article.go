@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -11,13 +13,19 @@ import (
 	"github.com/bxcodec/go-clean-arch/internal/repository"
 )
 
+// articleKeyErrors are what the violations of the article's unique keys are reported as
+var articleKeyErrors = map[string]error{
+	"title": domain.ErrTitleTaken,
+}
+
 type ArticleRepository struct {
-	Conn *sql.DB
+	Conn    *sql.DB
+	Cursors *repository.CursorCodec
 }
 
 // NewArticleRepository will create an object that represent the article.Repository interface
-func NewArticleRepository(conn *sql.DB) *ArticleRepository {
-	return &ArticleRepository{conn}
+func NewArticleRepository(conn *sql.DB, cursors *repository.CursorCodec) *ArticleRepository {
+	return &ArticleRepository{conn, cursors}
 }
 
 func (m *ArticleRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Article, err error) {
@@ -38,6 +46,7 @@ func (m *ArticleRepository) fetch(ctx context.Context, query string, args ...int
 	for rows.Next() {
 		t := domain.Article{}
 		authorID := int64(0)
+		publishAt := sql.NullTime{}
 		err = rows.Scan(
 			&t.ID,
 			&t.Title,
@@ -45,6 +54,13 @@ func (m *ArticleRepository) fetch(ctx context.Context, query string, args ...int
 			&authorID,
 			&t.UpdatedAt,
 			&t.CreatedAt,
+			&t.Status,
+			&publishAt,
+			&t.Slug,
+			&t.WordCount,
+			&t.ReadingMinutes,
+			&t.Excerpt,
+			&t.Version,
 		)
 
 		if err != nil {
@@ -54,35 +70,143 @@ func (m *ArticleRepository) fetch(ctx context.Context, query string, args ...int
 		t.Author = domain.Author{
 			ID: authorID,
 		}
+		if publishAt.Valid {
+			t.PublishAt = &publishAt.Time
+		}
 		result = append(result, t)
 	}
 
 	return result, nil
 }
 
-func (m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64) (res []domain.Article, nextCursor string, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
-  						FROM article WHERE created_at > ? ORDER BY created_at LIMIT ? `
+// Fetch pages through the articles matching the query's filters, sorted as it asks
+func (m *ArticleRepository) Fetch(ctx context.Context, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error) {
+	query := `SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at,
+  						article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version
+  						FROM article`
 
-	decodedCursor, err := repository.DecodeCursor(cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
-	}
+	conds, args, filter := articleFilters(q)
+	return m.fetchPage(ctx, query, conds, args, repository.FilterFingerprint(filter...), q)
+}
 
-	res, err = m.fetch(ctx, query, decodedCursor, num)
-	if err != nil {
-		return nil, "", err
+func (m *ArticleRepository) FetchByCategory(ctx context.Context, categoryID int64, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error) {
+	q.CategoryID = categoryID
+	return m.Fetch(ctx, q)
+}
+
+// articleFilters turns the query's filters into the conditions of a listing, along with the filter
+// going into the cursor fingerprint. The trashed articles are always left out
+func articleFilters(q domain.ArticleQuery) (conds []string, args []interface{}, filter []string) {
+	conds = []string{"article.deleted_at IS NULL"}
+	add := func(cond string, arg interface{}, name, value string) {
+		conds = append(conds, cond)
+		args = append(args, arg)
+		filter = append(filter, name, value)
 	}
 
-	if len(res) == int(num) {
-		nextCursor = repository.EncodeCursor(res[len(res)-1].CreatedAt)
+	if q.CategoryID != 0 {
+		add("article.id IN (SELECT ac.article_id FROM article_category ac WHERE ac.category_id = ?)",
+			q.CategoryID, "category", strconv.FormatInt(q.CategoryID, 10))
+	}
+	if q.Status != "" {
+		add("article.status = ?", q.Status, "status", string(q.Status))
+	}
+	if q.AuthorID != 0 {
+		add("article.author_id = ?", q.AuthorID, "author", strconv.FormatInt(q.AuthorID, 10))
+	}
+	if q.TitlePrefix != "" {
+		add("article.title LIKE ?", escapeLike(q.TitlePrefix)+"%", "title", q.TitlePrefix)
+	}
+	for _, bound := range []struct {
+		name, cond string
+		at         *time.Time
+	}{
+		{"created_from", "article.created_at >= ?", q.CreatedFrom},
+		{"created_to", "article.created_at < ?", q.CreatedTo},
+		{"updated_from", "article.updated_at >= ?", q.UpdatedFrom},
+		{"updated_to", "article.updated_at < ?", q.UpdatedTo},
+	} {
+		if bound.at != nil {
+			add(bound.cond, *bound.at, bound.name, bound.at.UTC().Format(time.RFC3339Nano))
+		}
 	}
 
+	if len(q.Tags) > 0 {
+		conds = append(conds, `article.id IN (SELECT at.article_id FROM article_tag at JOIN tag t ON t.id = at.tag_id
+  						WHERE t.name IN (`+placeholders(len(q.Tags))+`) GROUP BY at.article_id HAVING COUNT(*) = ?)`)
+		for _, tag := range q.Tags {
+			args = append(args, tag)
+		}
+		args = append(args, len(q.Tags))
+		filter = append(filter, append([]string{"tags"}, q.Tags...)...)
+	}
 	return
 }
+
+// articleSorts are the columns the articles can be sorted by
+var articleSorts = map[string]sortKey[domain.Article]{
+	string(domain.SortByCreatedAt): createdAtKey("article", func(ar domain.Article) time.Time {
+		return ar.CreatedAt
+	}),
+	string(domain.SortByUpdatedAt): {
+		column: "article.updated_at",
+		mark: func(ar domain.Article, cursor *repository.Cursor) {
+			updatedAt := ar.UpdatedAt
+			cursor.UpdatedAt = &updatedAt
+		},
+		at: func(cursor repository.Cursor) interface{} {
+			if cursor.UpdatedAt == nil {
+				return time.Time{}
+			}
+			return *cursor.UpdatedAt
+		},
+	},
+	// the unpublished articles have no publish_at, they sort by their creation so the keyset holds for them too
+	string(domain.SortByPublishAt): {
+		column: "COALESCE(article.publish_at, article.created_at)",
+		mark: func(ar domain.Article, cursor *repository.Cursor) {
+			publishAt := ar.CreatedAt
+			if ar.PublishAt != nil {
+				publishAt = *ar.PublishAt
+			}
+			cursor.PublishAt = &publishAt
+		},
+		at: func(cursor repository.Cursor) interface{} {
+			if cursor.PublishAt == nil {
+				return time.Time{}
+			}
+			return *cursor.PublishAt
+		},
+	},
+	string(domain.SortByTitle): {
+		column: "article.title",
+		mark: func(ar domain.Article, cursor *repository.Cursor) {
+			cursor.Title = ar.Title
+		},
+		at: func(cursor repository.Cursor) interface{} {
+			return cursor.Title
+		},
+	},
+}
+
+// fetchPage pages through the articles with a keyset on the sort column and the id
+func (m *ArticleRepository) fetchPage(ctx context.Context, query string, conds []string, args []interface{},
+	filter string, q domain.ArticleQuery) (res []domain.Article, page domain.Page, err error) {
+	pager := keysetPager[domain.Article]{
+		cursors: m.Cursors,
+		table:   "article",
+		fetch:   m.fetch,
+		id: func(ar domain.Article) int64 {
+			return ar.ID
+		},
+		sorts: articleSorts,
+	}
+	return pager.page(ctx, query, conds, args, filter, pageQuery{Cursor: q.Cursor, Num: q.Num, Order: q.Order, Sort: string(q.Sort)})
+}
+
 func (m *ArticleRepository) GetByID(ctx context.Context, id int64) (res domain.Article, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
-  						FROM article WHERE ID = ?`
+	query := `SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version
+  						FROM article WHERE ID = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, id)
 	if err != nil {
@@ -98,9 +222,62 @@ func (m *ArticleRepository) GetByID(ctx context.Context, id int64) (res domain.A
 	return
 }
 
+// GetByIDs returns the articles with the given ids, the ones that don't exist or are trashed are left out
+func (m *ArticleRepository) GetByIDs(ctx context.Context, ids []int64) (res []domain.Article, err error) {
+	if len(ids) == 0 {
+		return []domain.Article{}, nil
+	}
+
+	query := `SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version
+  						FROM article WHERE id IN (` + placeholders(len(ids)) + `) AND deleted_at IS NULL`
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return m.fetch(ctx, query, args...)
+}
+
+// GetBySlug returns the article by its current slug or one of its former slugs,
+// the article's Slug tells which one it was
+func (m *ArticleRepository) GetBySlug(ctx context.Context, slug string) (res domain.Article, err error) {
+	query := `SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version
+  						FROM article WHERE (slug = ? OR id = (SELECT article_id FROM article_slug WHERE slug = ?)) AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, slug, slug)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+// SlugExists reports whether another article than exceptID uses the slug or used it before,
+// the trashed articles keep their slugs as they can still be restored
+func (m *ArticleRepository) SlugExists(ctx context.Context, slug string, exceptID int64) (exists bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM article WHERE slug = ? AND id <> ?
+  						UNION ALL SELECT 1 FROM article_slug WHERE slug = ? AND article_id <> ?)`
+	err = m.Conn.QueryRowContext(ctx, query, slug, exceptID, slug, exceptID).Scan(&exists)
+	return
+}
+
+// TitleExists reports whether an article already uses the title within the scope, the trashed
+// articles keep their titles as they can still be restored
+func (m *ArticleRepository) TitleExists(ctx context.Context, scope int64, title string) (exists bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM article WHERE title_scope = ? AND title = ?)`
+	err = m.Conn.QueryRowContext(ctx, query, scope, title).Scan(&exists)
+	return
+}
+
 func (m *ArticleRepository) GetByTitle(ctx context.Context, title string) (res domain.Article, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
-  						FROM article WHERE title = ?`
+	query := `SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version
+  						FROM article WHERE title = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, title)
 	if err != nil {
@@ -115,34 +292,61 @@ func (m *ArticleRepository) GetByTitle(ctx context.Context, title string) (res d
 	return
 }
 
+// CountByAuthor returns how many articles were written by the given author,
+// the trashed ones count as they can still be restored
+func (m *ArticleRepository) CountByAuthor(ctx context.Context, authorID int64) (total int64, err error) {
+	query := `SELECT COUNT(*) FROM article WHERE author_id = ?`
+	err = m.Conn.QueryRowContext(ctx, query, authorID).Scan(&total)
+	return
+}
+
+// Store will insert the article along with its first revision, a title that's already taken is
+// reported as domain.ErrTitleTaken and a slug as domain.ErrConflict
 func (m *ArticleRepository) Store(ctx context.Context, a *domain.Article) (err error) {
-	query := `INSERT  article SET title=? , content=? , author_id=?, updated_at=? , created_at=?`
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	tx, err := m.Conn.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
+	defer rollbackOnError(tx, &err)
 
-	res, err := stmt.ExecContext(ctx, a.Title, a.Content, a.Author.ID, a.UpdatedAt, a.CreatedAt)
+	query := `INSERT  article SET title=? , title_scope=? , slug=? , content=? , word_count=? , reading_minutes=? , excerpt=? ,
+  						author_id=?, updated_at=? , created_at=? , status=? , publish_at=?`
+	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
+
+	res, err := stmt.ExecContext(ctx, a.Title, a.TitleScope, a.Slug, a.Content, a.WordCount, a.ReadingMinutes, a.Excerpt,
+		a.Author.ID, a.UpdatedAt, a.CreatedAt, a.Status, a.PublishAt)
+	if err != nil {
+		err = mapError(err, articleKeyErrors)
+		return
+	}
 	lastID, err := res.LastInsertId()
 	if err != nil {
 		return
 	}
 	a.ID = lastID
+	a.Version = 1
+
+	err = m.storeRevision(ctx, tx, *a)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
 	return
 }
 
-func (m *ArticleRepository) Delete(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM article WHERE id = ?"
+// Delete moves the article to the trash, it's left out of every read until it's restored or purged
+func (m *ArticleRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE article SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
 
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
 	if err != nil {
 		return
 	}
@@ -159,15 +363,182 @@ func (m *ArticleRepository) Delete(ctx context.Context, id int64) (err error) {
 
 	return
 }
+
+// Restore takes the article out of the trash
+func (m *ArticleRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := "UPDATE article SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		err = domain.ErrNotFound
+	}
+
+	return
+}
+
+// FetchTrash returns the trashed articles, the latest deleted first
+func (m *ArticleRepository) FetchTrash(ctx context.Context) (result []domain.Article, err error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, status, deleted_at
+  						FROM article WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	rows, err := m.Conn.QueryContext(ctx, query)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Article, 0)
+	for rows.Next() {
+		t := domain.Article{}
+		deletedAt := time.Time{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Content,
+			&t.Author.ID,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.Status,
+			&deletedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		t.DeletedAt = &deletedAt
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// Purge permanently removes the articles trashed before the given time, along with their
+// categories, tags, revisions, former slugs and comments
+func (m *ArticleRepository) Purge(ctx context.Context, before time.Time) (total int64, err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer rollbackOnError(tx, &err)
+
+	trashed := `SELECT id FROM article WHERE deleted_at < ?`
+	for _, query := range []string{
+		`DELETE FROM article_category WHERE article_id IN (` + trashed + `)`,
+		`DELETE FROM article_revision WHERE article_id IN (` + trashed + `)`,
+		`DELETE FROM article_slug WHERE article_id IN (` + trashed + `)`,
+		`DELETE FROM comment WHERE article_id IN (` + trashed + `)`,
+		`DELETE FROM article_tag WHERE article_id IN (` + trashed + `)`,
+		`DELETE FROM article_view WHERE article_id IN (` + trashed + `)`,
+	} {
+		_, err = tx.ExecContext(ctx, query, before)
+		if err != nil {
+			return
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM article WHERE deleted_at < ?`, before)
+	if err != nil {
+		return
+	}
+	total, err = res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// Update will only overwrite the article when its version still equals ar.Version, otherwise someone
+// else has changed it in between and domain.ErrPreconditionFailed is returned. ar.Version moves to the
+// new version. A new revision is written along with the article, and its slug goes to the history when it changes
 func (m *ArticleRepository) Update(ctx context.Context, ar *domain.Article) (err error) {
-	query := `UPDATE article set title=?, content=?, author_id=?, updated_at=? WHERE ID = ?`
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer rollbackOnError(tx, &err)
+
+	// the slug may have been used before, going back to a former title
+	history := `INSERT IGNORE article_slug (article_id, slug, created_at)
+  						SELECT id, slug, ? FROM article WHERE ID = ? AND slug <> ?`
+	_, err = tx.ExecContext(ctx, history, ar.UpdatedAt, ar.ID, ar.Slug)
+	if err != nil {
+		return
+	}
+
+	query := `UPDATE article set title=?, title_scope=?, slug=?, content=?, word_count=?, reading_minutes=?, excerpt=?,
+  						author_id=?, updated_at=?, version=version+1 WHERE ID = ? AND version = ? AND deleted_at IS NULL`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Title, ar.TitleScope, ar.Slug, ar.Content, ar.WordCount, ar.ReadingMinutes, ar.Excerpt,
+		ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version)
+	if err != nil {
+		err = mapError(err, articleKeyErrors)
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	err = m.storeRevision(ctx, tx, *ar)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+	ar.Version++
+	return
+}
+
+// UpdateStatus moves the article to ar.Status as long as it's still in the from status, otherwise
+// someone else has moved it in between and domain.ErrPreconditionFailed is returned. The version goes up too
+func (m *ArticleRepository) UpdateStatus(ctx context.Context, ar *domain.Article, from domain.ArticleStatus) (err error) {
+	query := `UPDATE article set status=?, publish_at=?, updated_at=?, version=version+1 WHERE ID = ? AND status = ? AND deleted_at IS NULL`
 
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID)
+	res, err := stmt.ExecContext(ctx, ar.Status, ar.PublishAt, ar.UpdatedAt, ar.ID, from)
 	if err != nil {
 		return
 	}
@@ -175,10 +546,105 @@ func (m *ArticleRepository) Update(ctx context.Context, ar *domain.Article) (err
 	if err != nil {
 		return
 	}
+	if affect == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
 	if affect != 1 {
 		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
 		return
 	}
+	ar.Version++
+	return
+}
+
+// FetchDue returns the scheduled articles whose publication time has come, the earliest first
+func (m *ArticleRepository) FetchDue(ctx context.Context, now time.Time) (res []domain.Article, err error) {
+	query := `SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version
+  						FROM article WHERE status = ? AND publish_at <= ? AND deleted_at IS NULL ORDER BY publish_at, id`
+	return m.fetch(ctx, query, domain.StatusScheduled, now)
+}
+
+// storeRevision snapshots the article as it's being saved
+func (m *ArticleRepository) storeRevision(ctx context.Context, tx *sql.Tx, ar domain.Article) (err error) {
+	query := `INSERT  article_revision SET article_id=? , title=? , content=? , author_id=?, created_at=?`
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, ar.ID, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt)
+	return
+}
+
+func (m *ArticleRepository) fetchRevisions(ctx context.Context, query string, args ...interface{}) (result []domain.Revision, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Revision, 0)
+	for rows.Next() {
+		r := domain.Revision{}
+		err = rows.Scan(
+			&r.ID,
+			&r.ArticleID,
+			&r.Title,
+			&r.Content,
+			&r.Author.ID,
+			&r.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// FetchRevisions returns the revisions of the article, the latest first
+func (m *ArticleRepository) FetchRevisions(ctx context.Context, articleID int64) (res []domain.Revision, err error) {
+	query := `SELECT id, article_id, title, content, author_id, created_at
+  						FROM article_revision WHERE article_id = ? ORDER BY id DESC`
+	return m.fetchRevisions(ctx, query, articleID)
+}
+
+func (m *ArticleRepository) GetRevision(ctx context.Context, id int64) (res domain.Revision, err error) {
+	query := `SELECT id, article_id, title, content, author_id, created_at
+  						FROM article_revision WHERE id = ?`
+
+	list, err := m.fetchRevisions(ctx, query, id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
 
 	return
 }
+
+// rollbackOnError rolls the transaction back when the function deferring it fails
+func rollbackOnError(tx *sql.Tx, err *error) {
+	if *err == nil {
+		return
+	}
+	errRollback := tx.Rollback()
+	if errRollback != nil {
+		logrus.Error(errRollback)
+	}
+}
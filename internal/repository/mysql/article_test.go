@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 
@@ -13,39 +14,128 @@ import (
 	articleMysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
 )
 
+var cursors = repository.NewCursorCodec([]byte("secret"))
+
+const revisionQuery = "INSERT  article_revision SET article_id=\\? , title=\\? , content=\\? , author_id=\\?, created_at=\\?"
+
+const slugHistoryQuery = "INSERT IGNORE article_slug \\(article_id, slug, created_at\\) SELECT id, slug, \\? FROM article WHERE ID = \\? AND slug <> \\?"
+
 func TestFetchArticle(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
 	mockArticles := []domain.Article{
 		{
 			ID: 1, Title: "title 1", Content: "content 1",
-			Author: domain.Author{ID: 1}, UpdatedAt: time.Now(), CreatedAt: time.Now(),
+			Author: domain.Author{ID: 1}, UpdatedAt: createdAt, CreatedAt: createdAt,
 		},
 		{
 			ID: 2, Title: "title 2", Content: "content 2",
-			Author: domain.Author{ID: 1}, UpdatedAt: time.Now(), CreatedAt: time.Now(),
+			Author: domain.Author{ID: 1}, UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+		{
+			ID: 3, Title: "title 3", Content: "content 3",
+			Author: domain.Author{ID: 1}, UpdatedAt: createdAt, CreatedAt: createdAt,
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
-		AddRow(mockArticles[0].ID, mockArticles[0].Title, mockArticles[0].Content,
-			mockArticles[0].Author.ID, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt).
-		AddRow(mockArticles[1].ID, mockArticles[1].Title, mockArticles[1].Content,
-			mockArticles[1].Author.ID, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"})
+	for _, ar := range mockArticles {
+		rows.AddRow(ar.ID, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.CreatedAt, ar.Status, nil, ar.Slug, 0, 0, "", 1)
+	}
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE created_at > \\? ORDER BY created_at LIMIT \\?"
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND \\(article.created_at > \\? OR \\(article.created_at = \\? AND article.id > \\?\\)\\) " +
+		"ORDER BY article.created_at ASC, article.id ASC LIMIT \\?"
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
-	a := articleMysqlRepo.NewArticleRepository(db)
-	cursor := repository.EncodeCursor(mockArticles[1].CreatedAt)
+	cursor := repository.Cursor{CreatedAt: createdAt, ID: 0, Order: domain.SortAsc, Filter: repository.FilterFingerprint()}
+	mock.ExpectQuery(query).WithArgs(cursor.CreatedAt, cursor.CreatedAt, cursor.ID, int64(3)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 	num := int64(2)
-	list, nextCursor, err := a.Fetch(context.TODO(), cursor, num)
-	assert.NotEmpty(t, nextCursor)
+	list, page, err := a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursors.EncodeCursor(cursor), Num: num})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), next.ID)
+	assert.False(t, next.Backward)
+
+	prev, err := cursors.DecodeCursor(page.Prev)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), prev.ID)
+	assert.True(t, prev.Backward)
+}
+
+func TestFetchArticleBackwardDescending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	// walking backward through a descending list reads the rows ascending
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(5, "title 5", "content 5", 1, createdAt, createdAt, "published", nil, "title-5", 0, 0, "", 1).
+		AddRow(6, "title 6", "content 6", 1, createdAt, createdAt, "published", nil, "title-6", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND \\(article.created_at > \\? OR \\(article.created_at = \\? AND article.id > \\?\\)\\) " +
+		"ORDER BY article.created_at ASC, article.id ASC LIMIT \\?"
+
+	cursor := repository.Cursor{CreatedAt: createdAt, ID: 4, Order: domain.SortDesc, Backward: true}
+	mock.ExpectQuery(query).WithArgs(cursor.CreatedAt, cursor.CreatedAt, cursor.ID, int64(3)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	list, page, err := a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursors.EncodeCursor(cursor), Num: 2})
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 	assert.Len(t, list, 2)
+	assert.Equal(t, int64(6), list[0].ID)
+	assert.Equal(t, int64(5), list[1].ID)
+	assert.Empty(t, page.Prev)
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), next.ID)
+	assert.Equal(t, domain.SortDesc, next.Order)
+}
+
+func TestFetchArticleCursorOfAnotherListing(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	categoryCursor := cursors.EncodeCursor(repository.Cursor{
+		ID: 4, Order: domain.SortAsc, Filter: repository.FilterFingerprint("category", "2"),
+	})
+
+	_, _, err = a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: categoryCursor, Num: 2})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	_, _, err = a.FetchByCategory(context.TODO(), 3, domain.ArticleQuery{Cursor: categoryCursor, Num: 2})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+
+	_, _, err = a.FetchByCategory(context.TODO(), 2, domain.ArticleQuery{Cursor: categoryCursor, Num: 2, Order: domain.SortDesc})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
+
+func TestFetchArticleBadCursor(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	_, _, err = a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: "not-a-cursor", Num: 2})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
 }
 
 func TestGetArticleByID(t *testing.T) {
@@ -54,13 +144,13 @@ func TestGetArticleByID(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), "published", nil, "title-1", 0, 0, "", 1)
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE ID = \\?"
+	query := "SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version FROM article WHERE ID = \\? AND deleted_at IS NULL"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
-	a := articleMysqlRepo.NewArticleRepository(db)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 
 	num := int64(5)
 	anArticle, err := a.GetByID(context.TODO(), num)
@@ -68,11 +158,32 @@ func TestGetArticleByID(t *testing.T) {
 	assert.NotNil(t, anArticle)
 }
 
+func TestGetArticleByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(3, "title 3", "Content 3", 1, time.Now(), time.Now(), "published", nil, "title-3", 0, 0, "", 1)
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version FROM article WHERE id IN \\(\\?, \\?\\) AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WithArgs(int64(3), int64(7)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	list, err := a.GetByIDs(context.TODO(), []int64{3, 7})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestStoreArticle(t *testing.T) {
 	now := time.Now()
 	ar := &domain.Article{
 		Title:     "Judul",
 		Content:   "Content",
+		Status:    domain.StatusDraft,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Author: domain.Author{
@@ -85,15 +196,75 @@ func TestStoreArticle(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "INSERT  article SET title=\\? , content=\\? , author_id=\\?, updated_at=\\? , created_at=\\?"
+	query := "INSERT  article SET title=\\? , title_scope=\\? , slug=\\? , content=\\? , word_count=\\? , reading_minutes=\\? , excerpt=\\? , author_id=\\?, updated_at=\\? , created_at=\\? , status=\\? , publish_at=\\?"
+	mock.ExpectBegin()
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.CreatedAt, ar.UpdatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(ar.Title, ar.TitleScope, ar.Slug, ar.Content, ar.WordCount, ar.ReadingMinutes, ar.Excerpt, ar.Author.ID, ar.CreatedAt, ar.UpdatedAt, domain.StatusDraft, nil).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep = mock.ExpectPrepare(revisionQuery)
+	prep.ExpectExec().WithArgs(12, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	a := articleMysqlRepo.NewArticleRepository(db)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 
 	err = a.Store(context.TODO(), ar)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), ar.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreArticleDuplicateTitle(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Article{
+		Title:     "Judul",
+		Slug:      "judul",
+		Content:   "Content",
+		Status:    domain.StatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   3,
+		Author:    domain.Author{ID: 1},
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  article SET title=\\? , title_scope=\\? , slug=\\?"
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.Title, ar.TitleScope, ar.Slug, ar.Content, ar.WordCount, ar.ReadingMinutes, ar.Excerpt, ar.Author.ID, ar.CreatedAt, ar.UpdatedAt, domain.StatusDraft, nil).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '0-Judul' for key 'title'"})
+	mock.ExpectRollback()
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	err = a.Store(context.TODO(), ar)
+	assert.ErrorIs(t, err, domain.ErrTitleTaken)
+	assert.Zero(t, ar.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreArticleDuplicateSlug(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Article{Title: "Judul", Slug: "judul", Content: "Content", Status: domain.StatusDraft,
+		CreatedAt: now, UpdatedAt: now, Author: domain.Author{ID: 1}}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	// MySQL 8 names the key along with its table
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare("INSERT  article SET title=\\? , title_scope=\\? , slug=\\?")
+	prep.ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'judul' for key 'article.slug'"})
+	mock.ExpectRollback()
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	err = a.Store(context.TODO(), ar)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.NotErrorIs(t, err, domain.ErrTitleTaken)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetArticleByTitle(t *testing.T) {
@@ -102,13 +273,13 @@ func TestGetArticleByTitle(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), "published", nil, "title-1", 0, 0, "", 1)
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE title = \\?"
+	query := "SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version FROM article WHERE title = \\? AND deleted_at IS NULL"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
-	a := articleMysqlRepo.NewArticleRepository(db)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 
 	title := "title 1"
 	anArticle, err := a.GetByTitle(context.TODO(), title)
@@ -116,22 +287,143 @@ func TestGetArticleByTitle(t *testing.T) {
 	assert.NotNil(t, anArticle)
 }
 
+func TestGetArticleBySlug(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), "published", nil, "title-1", 0, 0, "", 1)
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version FROM article " +
+		"WHERE \\(slug = \\? OR id = \\(SELECT article_id FROM article_slug WHERE slug = \\?\\)\\) AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WithArgs("judul-lama", "judul-lama").WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	anArticle, err := a.GetBySlug(context.TODO(), "judul-lama")
+	assert.NoError(t, err)
+	assert.Equal(t, "title-1", anArticle.Slug)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleSlugExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT EXISTS \\(SELECT 1 FROM article WHERE slug = \\? AND id <> \\? " +
+		"UNION ALL SELECT 1 FROM article_slug WHERE slug = \\? AND article_id <> \\?\\)"
+
+	mock.ExpectQuery(query).WithArgs("judul", int64(12), "judul", int64(12)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	exists, err := a.SlugExists(context.TODO(), "judul", 12)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleTitleExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT EXISTS \\(SELECT 1 FROM article WHERE title_scope = \\? AND title = \\?\\)"
+
+	mock.ExpectQuery(query).WithArgs(int64(3), "Judul").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	exists, err := a.TitleExists(context.TODO(), 3, "Judul")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteArticle(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "DELETE FROM article WHERE id = \\?"
+	query := "UPDATE article SET deleted_at = \\? WHERE id = \\? AND deleted_at IS NULL"
 
+	deletedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(deletedAt, 12).WillReturnResult(sqlmock.NewResult(12, 1))
 
-	a := articleMysqlRepo.NewArticleRepository(db)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 
 	num := int64(12)
-	err = a.Delete(context.TODO(), num)
+	err = a.Delete(context.TODO(), num, deletedAt)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreArticleNotTrashed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE article SET deleted_at = NULL WHERE id = \\? AND deleted_at IS NOT NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	err = a.Restore(context.TODO(), 12)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestFetchArticleTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	deletedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "deleted_at"}).
+		AddRow(12, "Judul", "Content", 1, deletedAt, deletedAt, "draft", deletedAt)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, status, deleted_at FROM article " +
+		"WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC"
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	list, err := a.FetchTrash(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, deletedAt, *list[0].DeletedAt)
+}
+
+func TestPurgeArticle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	before := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	trashed := "\\(SELECT id FROM article WHERE deleted_at < \\?\\)"
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM article_category WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("DELETE FROM article_revision WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec("DELETE FROM article_slug WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM comment WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM article_tag WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM article_view WHERE article_id IN " + trashed).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec("DELETE FROM article WHERE deleted_at < \\?").WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	total, err := a.Purge(context.TODO(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateArticle(t *testing.T) {
@@ -139,9 +431,11 @@ func TestUpdateArticle(t *testing.T) {
 	ar := &domain.Article{
 		ID:        12,
 		Title:     "Judul",
+		Slug:      "judul",
 		Content:   "Content",
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   3,
 		Author: domain.Author{
 			ID:   1,
 			Name: "Iman Tumorang",
@@ -153,13 +447,324 @@ func TestUpdateArticle(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "UPDATE article set title=\\?, content=\\?, author_id=\\?, updated_at=\\? WHERE ID = \\?"
+	query := "UPDATE article set title=\\?, title_scope=\\?, slug=\\?, content=\\?, word_count=\\?, reading_minutes=\\?, excerpt=\\?, author_id=\\?, updated_at=\\?, version=version\\+1 WHERE ID = \\? AND version = \\? AND deleted_at IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(slugHistoryQuery).WithArgs(ar.UpdatedAt, ar.ID, ar.Slug).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.Title, ar.TitleScope, ar.Slug, ar.Content, ar.WordCount, ar.ReadingMinutes, ar.Excerpt, ar.Author.ID, ar.UpdatedAt, ar.ID, int64(3)).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep = mock.ExpectPrepare(revisionQuery)
+	prep.ExpectExec().WithArgs(ar.ID, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	err = a.Update(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), ar.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateArticleModifiedConcurrently(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Article{
+		ID:        12,
+		Title:     "Judul",
+		Slug:      "judul",
+		Content:   "Content",
+		UpdatedAt: now,
+		Version:   3,
+		Author:    domain.Author{ID: 1},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE article set title=\\?, title_scope=\\?, slug=\\?, content=\\?, word_count=\\?, reading_minutes=\\?, excerpt=\\?, author_id=\\?, updated_at=\\?, version=version\\+1 WHERE ID = \\? AND version = \\? AND deleted_at IS NULL"
 
+	mock.ExpectBegin()
+	mock.ExpectExec(slugHistoryQuery).WithArgs(ar.UpdatedAt, ar.ID, ar.Slug).WillReturnResult(sqlmock.NewResult(0, 1))
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(ar.Title, ar.TitleScope, ar.Slug, ar.Content, ar.WordCount, ar.ReadingMinutes, ar.Excerpt, ar.Author.ID, ar.UpdatedAt, ar.ID, int64(3)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
 
-	a := articleMysqlRepo.NewArticleRepository(db)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
 
 	err = a.Update(context.TODO(), ar)
+	assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+	assert.Equal(t, int64(3), ar.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchArticleRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "article_id", "title", "content", "author_id", "created_at"}).
+		AddRow(5, 12, "Judul", "Content 2", 1, createdAt.Add(time.Hour)).
+		AddRow(2, 12, "Judul", "Content 1", 1, createdAt)
+
+	query := "SELECT id, article_id, title, content, author_id, created_at FROM article_revision WHERE article_id = \\? ORDER BY id DESC"
+	mock.ExpectQuery(query).WithArgs(int64(12)).WillReturnRows(rows)
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+	list, err := a.FetchRevisions(context.TODO(), 12)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, int64(5), list[0].ID)
+	assert.Equal(t, int64(1), list[0].Author.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchArticleByCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), "published", nil, "title-1", 0, 0, "", 1).
+		AddRow(2, "title 2", "Content 2", 1, time.Now(), time.Now(), "published", nil, "title-2", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND article.id IN \\(SELECT ac.article_id FROM article_category ac WHERE ac.category_id = \\?\\) " +
+		"AND article.status = \\? ORDER BY article.created_at DESC, article.id DESC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(int64(2), domain.StatusPublished, int64(2)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	list, page, err := a.FetchByCategory(context.TODO(), 2, domain.ArticleQuery{Num: 1, Order: domain.SortDesc, Status: domain.StatusPublished})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, page.Next)
+	assert.Empty(t, page.Prev)
+	assert.Len(t, list, 1)
+	assert.Equal(t, domain.StatusPublished, list[0].Status)
+
+	// the cursor is bound to the status it was made for
+	_, _, err = a.FetchByCategory(context.TODO(), 2, domain.ArticleQuery{Cursor: page.Next, Num: 1, Status: domain.StatusDraft})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
+
+func TestFetchArticleSortedByTitle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(8, "Golang", "Content 8", 2, createdAt, createdAt, "published", nil, "golang", 0, 0, "", 1).
+		AddRow(3, "Gopher", "Content 3", 2, createdAt, createdAt, "published", nil, "gopher", 0, 0, "", 1).
+		AddRow(5, "Gophers", "Content 5", 2, createdAt, createdAt, "published", nil, "gophers", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND article.author_id = \\? AND article.title LIKE \\? " +
+		"AND article.created_at >= \\? AND article.created_at < \\? " +
+		"AND \\(article.title < \\? OR \\(article.title = \\? AND article.id < \\?\\)\\) " +
+		"ORDER BY article.title DESC, article.id DESC LIMIT \\?"
+
+	from, to := createdAt.Add(-24*time.Hour), createdAt.Add(24*time.Hour)
+	q := domain.ArticleQuery{Num: 2, AuthorID: 2, TitlePrefix: "go", CreatedFrom: &from, CreatedTo: &to}
+	filter := repository.FilterFingerprint("author", "2", "title", "go",
+		"created_from", from.Format(time.RFC3339Nano), "created_to", to.Format(time.RFC3339Nano))
+	cursor := repository.Cursor{Title: "Gopherz", ID: 9, Order: domain.SortDesc, Sort: "title", Filter: filter}
+	q.Cursor = cursors.EncodeCursor(cursor)
+	mock.ExpectQuery(query).WithArgs(int64(2), "go%", from, to, "Gopherz", "Gopherz", int64(9), int64(3)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	list, page, err := a.Fetch(context.TODO(), q)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, list, 2)
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, "title", next.Sort)
+	assert.Equal(t, "Gopher", next.Title)
+	assert.Equal(t, int64(3), next.ID)
+
+	// the cursor is bound to the sort it was made for
+	q.Sort = domain.SortByUpdatedAt
+	_, _, err = a.Fetch(context.TODO(), q)
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
+
+func TestFetchArticleSortedByUpdatedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(2, "title 2", "Content 2", 1, updatedAt, updatedAt, "published", nil, "title-2", 0, 0, "", 1).
+		AddRow(1, "title 1", "Content 1", 1, updatedAt.Add(time.Hour), updatedAt, "published", nil, "title-1", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL ORDER BY article.updated_at ASC, article.id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	_, page, err := a.Fetch(context.TODO(), domain.ArticleQuery{Num: 1, Sort: domain.SortByUpdatedAt})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated_at", next.Sort)
+	assert.Equal(t, updatedAt, *next.UpdatedAt)
+}
+
+func TestFetchArticleSortedByPublishAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	publishAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, publishAt, createdAt, "published", publishAt, "title-1", 0, 0, "", 1).
+		AddRow(2, "title 2", "Content 2", 1, publishAt, createdAt.Add(time.Hour), "published", publishAt.Add(-time.Hour), "title-2", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND article.status = \\? " +
+		"ORDER BY COALESCE\\(article.publish_at, article.created_at\\) DESC, article.id DESC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(domain.StatusPublished, int64(2)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	_, page, err := a.Fetch(context.TODO(), domain.ArticleQuery{
+		Num: 1, Order: domain.SortDesc, Sort: domain.SortByPublishAt, Status: domain.StatusPublished,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, "publish_at", next.Sort)
+	assert.Equal(t, publishAt, *next.PublishAt)
+}
+
+func TestFetchArticleSortedByPublishAtUnpublished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	// the drafts have no publish_at, they are paged through by their creation
+	createdAt := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(3, "title 3", "Content 3", 1, createdAt, createdAt, "draft", nil, "title-3", 0, 0, "", 1).
+		AddRow(4, "title 4", "Content 4", 1, createdAt, createdAt, "draft", nil, "title-4", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND \\(COALESCE\\(article.publish_at, article.created_at\\) > \\? OR " +
+		"\\(COALESCE\\(article.publish_at, article.created_at\\) = \\? AND article.id > \\?\\)\\) " +
+		"ORDER BY COALESCE\\(article.publish_at, article.created_at\\) ASC, article.id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(createdAt, createdAt, int64(2), int64(2)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	cursor := cursors.EncodeCursor(repository.Cursor{ID: 2, Order: domain.SortAsc, Sort: "publish_at", PublishAt: &createdAt})
+	list, page, err := a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursor, Num: 1})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), next.ID)
+	assert.Equal(t, createdAt, *next.PublishAt)
+}
+
+func TestFetchArticleByTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), "published", nil, "title-1", 0, 0, "", 1)
+
+	query := "SELECT article.id, article.title, article.content, article.author_id, article.updated_at, article.created_at, " +
+		"article.status, article.publish_at, article.slug, article.word_count, article.reading_minutes, article.excerpt, article.version FROM article " +
+		"WHERE article.deleted_at IS NULL AND article.id IN \\(SELECT at.article_id FROM article_tag at JOIN tag t ON t.id = at.tag_id " +
+		"WHERE t.name IN \\(\\?, \\?\\) GROUP BY at.article_id HAVING COUNT\\(\\*\\) = \\?\\) " +
+		"ORDER BY article.created_at ASC, article.id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs("go", "mysql", 2, int64(3)).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	list, _, err := a.Fetch(context.TODO(), domain.ArticleQuery{Num: 2, Tags: []string{"go", "mysql"}})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// the cursor is bound to the tags it was made for
+	cursor := cursors.EncodeCursor(repository.Cursor{ID: 1, Order: domain.SortAsc, Filter: repository.FilterFingerprint("tags", "go", "mysql")})
+	_, _, err = a.Fetch(context.TODO(), domain.ArticleQuery{Cursor: cursor, Num: 2, Tags: []string{"go"}})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
+
+func TestUpdateArticleStatus(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ar := &domain.Article{
+		ID:        12,
+		Status:    domain.StatusPublished,
+		PublishAt: &now,
+		UpdatedAt: now,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE article set status=\\?, publish_at=\\?, updated_at=\\?, version=version\\+1 WHERE ID = \\? AND status = \\? AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.Status, ar.PublishAt, ar.UpdatedAt, ar.ID, domain.StatusScheduled).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	err = a.UpdateStatus(context.TODO(), ar, domain.StatusScheduled)
+	assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchDueArticles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	publishAt := now.Add(-time.Minute)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "status", "publish_at", "slug", "word_count", "reading_minutes", "excerpt", "version"}).
+		AddRow(12, "Judul", "Content", 1, now, now, "scheduled", publishAt, "judul", 0, 0, "", 1)
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, status, publish_at, slug, word_count, reading_minutes, excerpt, version FROM article " +
+		"WHERE status = \\? AND publish_at <= \\? AND deleted_at IS NULL ORDER BY publish_at, id"
+
+	mock.ExpectQuery(query).WithArgs(domain.StatusScheduled, now).WillReturnRows(rows)
+	a := articleMysqlRepo.NewArticleRepository(db, cursors)
+
+	list, err := a.FetchDue(context.TODO(), now)
 	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, domain.StatusScheduled, list[0].Status)
+	assert.Equal(t, publishAt, *list[0].PublishAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
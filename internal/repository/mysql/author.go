@@ -3,6 +3,9 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/bxcodec/go-clean-arch/domain"
 )
@@ -31,10 +34,121 @@ func (m *AuthorRepository) getOne(ctx context.Context, query string, args ...int
 		&res.CreatedAt,
 		&res.UpdatedAt,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrNotFound
+	}
 	return
 }
 
+func (m *AuthorRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Author, err error) {
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Author, 0)
+	for rows.Next() {
+		a := domain.Author{}
+		err = rows.Scan(
+			&a.ID,
+			&a.Name,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, nil
+}
+
+func (m *AuthorRepository) Fetch(ctx context.Context) ([]domain.Author, error) {
+	query := `SELECT id, name, created_at, updated_at FROM author ORDER BY id`
+	return m.fetch(ctx, query)
+}
+
 func (m *AuthorRepository) GetByID(ctx context.Context, id int64) (domain.Author, error) {
 	query := `SELECT id, name, created_at, updated_at FROM author WHERE id=?`
 	return m.getOne(ctx, query, id)
 }
+
+// GetByIDs loads every given author with a single query, unknown ids are simply left out
+func (m *AuthorRepository) GetByIDs(ctx context.Context, ids []int64) ([]domain.Author, error) {
+	if len(ids) == 0 {
+		return []domain.Author{}, nil
+	}
+
+	query := `SELECT id, name, created_at, updated_at FROM author WHERE id IN (` + placeholders(len(ids)) + `)`
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return m.fetch(ctx, query, args...)
+}
+
+func (m *AuthorRepository) Store(ctx context.Context, a *domain.Author) (err error) {
+	query := `INSERT  author SET name=? , created_at=? , updated_at=?`
+	stmt, err := m.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, a.Name, a.CreatedAt, a.UpdatedAt)
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+// Update will overwrite the author's name, MySQL reports no affected row when nothing
+// changed so the caller is expected to check the author exists beforehand
+func (m *AuthorRepository) Update(ctx context.Context, a *domain.Author) (err error) {
+	query := `UPDATE author set name=?, updated_at=? WHERE id = ?`
+	stmt, err := m.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, a.Name, a.UpdatedAt, a.ID)
+	return
+}
+
+func (m *AuthorRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := `DELETE FROM author WHERE id = ?`
+	stmt, err := m.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+	}
+	return
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 
+	"github.com/bxcodec/go-clean-arch/domain"
 	repository "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
 )
 
@@ -32,3 +33,102 @@ func TestGetAuthorByID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, anArticle)
 }
+
+func TestGetAuthorByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "updated_at", "created_at"})
+
+	query := "SELECT id, name, created_at, updated_at FROM author WHERE id=\\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(int64(7)).WillReturnRows(rows)
+
+	a := repository.NewAuthorRepository(db)
+
+	_, err = a.GetByID(context.TODO(), 7)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestFetchAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(2, "Bxcodec", time.Now(), time.Now())
+
+	query := "SELECT id, name, created_at, updated_at FROM author ORDER BY id"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	a := repository.NewAuthorRepository(db)
+
+	list, err := a.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestStoreAuthor(t *testing.T) {
+	au := &domain.Author{
+		Name:      "Iman Tumorang",
+		CreatedAt: "2017-05-18 13:50:19",
+		UpdatedAt: "2017-05-18 13:50:19",
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  author SET name=\\? , created_at=\\? , updated_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(au.Name, au.CreatedAt, au.UpdatedAt).WillReturnResult(sqlmock.NewResult(3, 1))
+
+	a := repository.NewAuthorRepository(db)
+
+	err = a.Store(context.TODO(), au)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), au.ID)
+}
+
+func TestDeleteAuthorNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM author WHERE id = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(int64(12)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	a := repository.NewAuthorRepository(db)
+
+	err = a.Delete(context.TODO(), 12)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetAuthorByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(3, "Bxcodec", time.Now(), time.Now())
+
+	query := "SELECT id, name, created_at, updated_at FROM author WHERE id IN \\(\\?, \\?, \\?\\)"
+
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(2), int64(3)).WillReturnRows(rows)
+	a := repository.NewAuthorRepository(db)
+
+	list, err := a.GetByIDs(context.TODO(), []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
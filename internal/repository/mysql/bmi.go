@@ -4,9 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
+
 	"github.com/bxcodec/go-clean-arch/domain"
 )
 
+// bmiColumns are the columns of a record, in the order scanBMI reads them
+const bmiColumns = `id, height, weight, value, height_unit, weight_unit, birth_date, sex, waist, activity_level,
+	bmr_mifflin_st_jeor, bmr_harris_benedict, tdee, body_fat, waist_to_height, healthy_weight_min, healthy_weight_max,
+	created_at`
+
 type BMIRepository struct {
 	Conn *sql.DB
 }
@@ -18,14 +25,18 @@ func NewBMIRepository(conn *sql.DB) *BMIRepository {
 }
 
 func (m *BMIRepository) Store(ctx context.Context, bmi *domain.BMI) error {
-	query := `INSERT INTO bmi_records(height, weight, value, created_at) VALUES(?,?,?,NOW())`
+	query := `INSERT INTO bmi_records(height, weight, value, height_unit, weight_unit, birth_date, sex, waist, activity_level,
+		bmr_mifflin_st_jeor, bmr_harris_benedict, tdee, body_fat, waist_to_height, healthy_weight_min, healthy_weight_max,
+		created_at) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,NOW())`
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	res, err := stmt.ExecContext(ctx, bmi.Height, bmi.Weight, bmi.Value)
+	args := append([]any{bmi.Height, bmi.Weight, bmi.Value, bmi.EnteredHeightUnit, bmi.EnteredWeightUnit,
+		nullDate(bmi.BirthDate), bmi.Sex, bmi.Waist, bmi.ActivityLevel}, metricsArgs(bmi.Metrics)...)
+	res, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		return err
 	}
@@ -39,11 +50,10 @@ func (m *BMIRepository) Store(ctx context.Context, bmi *domain.BMI) error {
 }
 
 func (m *BMIRepository) GetByID(ctx context.Context, id int64) (*domain.BMI, error) {
-	query := `SELECT id, height, weight, value, created_at FROM bmi_records WHERE id = ?`
+	query := `SELECT ` + bmiColumns + ` FROM bmi_records WHERE id = ? AND deleted_at IS NULL`
 	row := m.Conn.QueryRowContext(ctx, query, id)
 
-	bmi := &domain.BMI{}
-	err := row.Scan(&bmi.ID, &bmi.Height, &bmi.Weight, &bmi.Value, &bmi.CreatedAt)
+	bmi, err := scanBMI(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -54,7 +64,7 @@ func (m *BMIRepository) GetByID(ctx context.Context, id int64) (*domain.BMI, err
 }
 
 func (m *BMIRepository) GetAll(ctx context.Context) ([]*domain.BMI, error) {
-	query := `SELECT id, height, weight, value, created_at FROM bmi_records`
+	query := `SELECT ` + bmiColumns + ` FROM bmi_records WHERE deleted_at IS NULL`
 	rows, err := m.Conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -63,8 +73,8 @@ func (m *BMIRepository) GetAll(ctx context.Context) ([]*domain.BMI, error) {
 
 	var bmis []*domain.BMI
 	for rows.Next() {
-		bmi := &domain.BMI{}
-		if err := rows.Scan(&bmi.ID, &bmi.Height, &bmi.Weight, &bmi.Value, &bmi.CreatedAt); err != nil {
+		bmi, err := scanBMI(rows)
+		if err != nil {
 			return nil, err
 		}
 		bmis = append(bmis, bmi)
@@ -78,14 +88,19 @@ func (m *BMIRepository) GetAll(ctx context.Context) ([]*domain.BMI, error) {
 }
 
 func (m *BMIRepository) Update(ctx context.Context, bmi *domain.BMI) error {
-	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ? WHERE id = ?`
+	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ?, height_unit = ?, weight_unit = ?,
+		birth_date = ?, sex = ?, waist = ?, activity_level = ?, bmr_mifflin_st_jeor = ?, bmr_harris_benedict = ?,
+		tdee = ?, body_fat = ?, waist_to_height = ?, healthy_weight_min = ?, healthy_weight_max = ?
+		WHERE id = ? AND deleted_at IS NULL`
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	res, err := stmt.ExecContext(ctx, bmi.Height, bmi.Weight, bmi.Value, bmi.ID)
+	args := append([]any{bmi.Height, bmi.Weight, bmi.Value, bmi.EnteredHeightUnit, bmi.EnteredWeightUnit,
+		nullDate(bmi.BirthDate), bmi.Sex, bmi.Waist, bmi.ActivityLevel}, metricsArgs(bmi.Metrics)...)
+	res, err := stmt.ExecContext(ctx, append(args, bmi.ID)...)
 	if err != nil {
 		return err
 	}
@@ -100,15 +115,16 @@ func (m *BMIRepository) Update(ctx context.Context, bmi *domain.BMI) error {
 	return nil
 }
 
-func (m *BMIRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM bmi_records WHERE id = ?`
+// Delete moves the record to the trash, it's left out of every read until it's restored or purged
+func (m *BMIRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	query := `UPDATE bmi_records SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
 	if err != nil {
 		return err
 	}
@@ -122,3 +138,104 @@ func (m *BMIRepository) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// Restore takes the record out of the trash
+func (m *BMIRepository) Restore(ctx context.Context, id int64) error {
+	query := `UPDATE bmi_records SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetTrash returns the trashed records, the latest deleted first
+func (m *BMIRepository) GetTrash(ctx context.Context) ([]*domain.BMI, error) {
+	query := `SELECT ` + bmiColumns + `, deleted_at FROM bmi_records
+		WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+	rows, err := m.Conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bmis := []*domain.BMI{}
+	for rows.Next() {
+		var deletedAt time.Time
+		bmi, err := scanBMI(rows, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		bmi.DeletedAt = &deletedAt
+		bmis = append(bmis, bmi)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bmis, nil
+}
+
+// Purge permanently removes the records trashed before the given time
+func (m *BMIRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM bmi_records WHERE deleted_at < ?`
+	res, err := m.Conn.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// scanBMI reads a record of bmiColumns, then the extra columns selected after them
+func scanBMI(row interface{ Scan(dest ...any) error }, extra ...any) (*domain.BMI, error) {
+	bmi := &domain.BMI{}
+	metrics := &domain.BodyMetrics{}
+	var birthDate sql.NullTime
+	dest := append([]any{&bmi.ID, &bmi.Height, &bmi.Weight, &bmi.Value, &bmi.EnteredHeightUnit,
+		&bmi.EnteredWeightUnit, &birthDate, &bmi.Sex, &bmi.Waist, &bmi.ActivityLevel,
+		&metrics.BMRMifflinStJeor, &metrics.BMRHarrisBenedict, &metrics.TDEE, &metrics.BodyFat, &metrics.WaistToHeight,
+		&metrics.HealthyWeightMin, &metrics.HealthyWeightMax, &bmi.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if birthDate.Valid {
+		bmi.BirthDate = birthDate.Time.Format(time.DateOnly)
+	}
+	// the records stored before the metrics were estimated have none
+	if *metrics != (domain.BodyMetrics{}) {
+		bmi.Metrics = metrics
+	}
+	return bmi, nil
+}
+
+// metricsArgs are the values of the metrics columns, the missing metrics are stored as NULL
+func metricsArgs(metrics *domain.BodyMetrics) []any {
+	if metrics == nil {
+		metrics = &domain.BodyMetrics{}
+	}
+	return []any{metrics.BMRMifflinStJeor, metrics.BMRHarrisBenedict, metrics.TDEE, metrics.BodyFat,
+		metrics.WaistToHeight, metrics.HealthyWeightMin, metrics.HealthyWeightMax}
+}
+
+// nullDate stores an empty date as NULL
+func nullDate(date string) any {
+	if date == "" {
+		return nil
+	}
+	return date
+}
@@ -14,21 +14,36 @@ import (
 	"github.com/bxcodec/go-clean-arch/domain"
 )
 
+// bmiColumns are the columns the records are read from
+var bmiColumns = []string{"id", "height", "weight", "value", "height_unit", "weight_unit", "birth_date", "sex", "waist",
+	"activity_level", "bmr_mifflin_st_jeor", "bmr_harris_benedict", "tdee", "body_fat", "waist_to_height",
+	"healthy_weight_min", "healthy_weight_max", "created_at"}
+
 func TestBMIRepository_Store(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := "INSERT INTO bmi_records(height, weight, value, created_at) VALUES(?,?,?,NOW())"
+	waistToHeight, bodyFat := 0.35, 32.5
+	query := "INSERT INTO bmi_records(height, weight, value, height_unit, weight_unit, birth_date, sex, waist, activity_level, " +
+		"bmr_mifflin_st_jeor, bmr_harris_benedict, tdee, body_fat, waist_to_height, healthy_weight_min, healthy_weight_max, created_at) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,NOW())"
 	bmi := &domain.BMI{
-		Height: 1.70,
-		Weight: 70.0,
-		Value:  24.221453287197235,
+		Height:            1.70,
+		Weight:            70.0,
+		Value:             24.221453287197235,
+		EnteredHeightUnit: "cm",
+		EnteredWeightUnit: "kg",
+		BirthDate:         "2015-06-01",
+		Sex:               "female",
+		Waist:             0.6,
+		ActivityLevel:     "light",
+		Metrics:           &domain.BodyMetrics{WaistToHeight: &waistToHeight, BodyFat: &bodyFat},
 	}
 
 	mock.ExpectPrepare(regexp.QuoteMeta(query)).
 		ExpectExec().
-		WithArgs(bmi.Height, bmi.Weight, bmi.Value).
+		WithArgs(bmi.Height, bmi.Weight, bmi.Value, "cm", "kg", "2015-06-01", "female", 0.6, "light",
+			nil, nil, nil, bodyFat, waistToHeight, nil, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := repository.NewBMIRepository(db)
@@ -43,13 +58,15 @@ func TestBMIRepository_GetByID(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := "SELECT id, height, weight, value, created_at FROM bmi_records WHERE id = ?"
+	query := "SELECT id, height, weight, value, height_unit, weight_unit, birth_date, sex, waist, activity_level, " +
+		"bmr_mifflin_st_jeor, bmr_harris_benedict, tdee, body_fat, waist_to_height, healthy_weight_min, healthy_weight_max, created_at FROM bmi_records WHERE id = ? AND deleted_at IS NULL"
 	id := int64(1)
 	bmiValue := 24.221453287197235
 	createdAt := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "height", "weight", "value", "created_at"}).
-		AddRow(id, 1.75, 70.0, bmiValue, createdAt)
+	rows := sqlmock.NewRows(bmiColumns).
+		AddRow(id, 1.75, 70.0, bmiValue, "ft", "lb", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC), "male", 0.8, "active",
+			nil, nil, nil, 12.4, 0.457, 56.7, 76.6, createdAt)
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
 		WithArgs(id).
@@ -64,6 +81,16 @@ func TestBMIRepository_GetByID(t *testing.T) {
 	assert.Equal(t, 1.75, bmi.Height)
 	assert.Equal(t, 70.0, bmi.Weight)
 	assert.Equal(t, bmiValue, bmi.Value)
+	assert.Equal(t, "ft", bmi.EnteredHeightUnit)
+	assert.Equal(t, "lb", bmi.EnteredWeightUnit)
+	assert.Equal(t, "2015-06-01", bmi.BirthDate)
+	assert.Equal(t, "male", bmi.Sex)
+	assert.Equal(t, 0.8, bmi.Waist)
+	assert.Equal(t, "active", bmi.ActivityLevel)
+	require.NotNil(t, bmi.Metrics)
+	assert.Nil(t, bmi.Metrics.BMRMifflinStJeor)
+	assert.Equal(t, 12.4, *bmi.Metrics.BodyFat)
+	assert.Equal(t, 76.6, *bmi.Metrics.HealthyWeightMax)
 	assert.WithinDuration(t, createdAt, time.Now(), time.Second)
 }
 
@@ -72,14 +99,15 @@ func TestBMIRepository_GetAll(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := "SELECT id, height, weight, value, created_at FROM bmi_records"
+	query := "SELECT id, height, weight, value, height_unit, weight_unit, birth_date, sex, waist, activity_level, " +
+		"bmr_mifflin_st_jeor, bmr_harris_benedict, tdee, body_fat, waist_to_height, healthy_weight_min, healthy_weight_max, created_at FROM bmi_records WHERE deleted_at IS NULL"
 
 	createdAt1 := time.Now().Add(-1 * time.Hour)
 	createdAt2 := time.Now().Add(-2 * time.Hour)
 
-	rows := sqlmock.NewRows([]string{"id", "height", "weight", "value", "created_at"}).
-		AddRow(1, 1.75, 70.0, 22.857142857142858, createdAt1).
-		AddRow(2, 1.80, 75.0, 23.148148148148145, createdAt2)
+	rows := sqlmock.NewRows(bmiColumns).
+		AddRow(1, 1.75, 70.0, 22.857142857142858, "m", "kg", nil, "", 0.0, "", nil, nil, nil, nil, nil, nil, nil, createdAt1).
+		AddRow(2, 1.80, 75.0, 23.148148148148145, "m", "kg", nil, "", 0.0, "", nil, nil, nil, nil, nil, nil, nil, createdAt2)
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
 		WillReturnRows(rows)
@@ -93,6 +121,7 @@ func TestBMIRepository_GetAll(t *testing.T) {
 	assert.Equal(t, 1.75, bmis[0].Height)
 	assert.Equal(t, 70.0, bmis[0].Weight)
 	assert.Equal(t, 22.857142857142858, bmis[0].Value)
+	assert.Nil(t, bmis[0].Metrics)
 	assert.WithinDuration(t, createdAt1, bmis[0].CreatedAt, time.Second)
 
 	assert.Equal(t, int64(2), bmis[1].ID)
@@ -107,17 +136,21 @@ func TestBMIRepository_Update(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ? WHERE id = ?`
+	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ?, height_unit = ?, weight_unit = ?, birth_date = ?, sex = ?,
+		waist = ?, activity_level = ?, bmr_mifflin_st_jeor = ?, bmr_harris_benedict = ?, tdee = ?, body_fat = ?, waist_to_height = ?,
+		healthy_weight_min = ?, healthy_weight_max = ? WHERE id = ? AND deleted_at IS NULL`
 	bmi := &domain.BMI{
-		ID:     1,
-		Height: 1.75,
-		Weight: 75.0,
-		Value:  24.49,
+		ID:                1,
+		Height:            1.75,
+		Weight:            75.0,
+		Value:             24.49,
+		EnteredHeightUnit: "m",
+		EnteredWeightUnit: "kg",
 	}
 
 	mock.ExpectPrepare(regexp.QuoteMeta(query)).
 		ExpectExec().
-		WithArgs(bmi.Height, bmi.Weight, bmi.Value, bmi.ID).
+		WithArgs(bmi.Height, bmi.Weight, bmi.Value, "m", "kg", nil, "", 0.0, "", nil, nil, nil, nil, nil, nil, nil, bmi.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := repository.NewBMIRepository(db)
@@ -130,17 +163,21 @@ func TestBMIRepository_Update_NoRows(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ? WHERE id = ?`
+	query := `UPDATE bmi_records SET height = ?, weight = ?, value = ?, height_unit = ?, weight_unit = ?, birth_date = ?, sex = ?,
+		waist = ?, activity_level = ?, bmr_mifflin_st_jeor = ?, bmr_harris_benedict = ?, tdee = ?, body_fat = ?, waist_to_height = ?,
+		healthy_weight_min = ?, healthy_weight_max = ? WHERE id = ? AND deleted_at IS NULL`
 	bmi := &domain.BMI{
-		ID:     999, // Non-existent ID
-		Height: 1.75,
-		Weight: 75.0,
-		Value:  24.49,
+		ID:                999, // Non-existent ID
+		Height:            1.75,
+		Weight:            75.0,
+		Value:             24.49,
+		EnteredHeightUnit: "m",
+		EnteredWeightUnit: "kg",
 	}
 
 	mock.ExpectPrepare(regexp.QuoteMeta(query)).
 		ExpectExec().
-		WithArgs(bmi.Height, bmi.Weight, bmi.Value, bmi.ID).
+		WithArgs(bmi.Height, bmi.Weight, bmi.Value, "m", "kg", nil, "", 0.0, "", nil, nil, nil, nil, nil, nil, nil, bmi.ID).
 		WillReturnResult(sqlmock.NewResult(0, 0)) // No rows affected
 
 	repo := repository.NewBMIRepository(db)
@@ -154,16 +191,17 @@ func TestBMIRepository_Delete(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := `DELETE FROM bmi_records WHERE id = ?`
+	query := `UPDATE bmi_records SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	id := int64(1)
+	deletedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
 
 	mock.ExpectPrepare(regexp.QuoteMeta(query)).
 		ExpectExec().
-		WithArgs(id).
+		WithArgs(deletedAt, id).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := repository.NewBMIRepository(db)
-	err = repo.Delete(context.Background(), id)
+	err = repo.Delete(context.Background(), id, deletedAt)
 	require.NoError(t, err)
 }
 
@@ -172,16 +210,53 @@ func TestBMIRepository_Delete_NoRows(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	query := `DELETE FROM bmi_records WHERE id = ?`
+	query := `UPDATE bmi_records SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 	id := int64(999)
+	deletedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
 
 	mock.ExpectPrepare(regexp.QuoteMeta(query)).
 		ExpectExec().
-		WithArgs(id).
+		WithArgs(deletedAt, id).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	repo := repository.NewBMIRepository(db)
-	err = repo.Delete(context.Background(), id)
+	err = repo.Delete(context.Background(), id, deletedAt)
 	require.Error(t, err)
 	assert.Equal(t, "no record found to delete", err.Error())
 }
+
+func TestBMIRepository_Restore_NotTrashed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := `UPDATE bmi_records SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+	id := int64(1)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(query)).
+		ExpectExec().
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repository.NewBMIRepository(db)
+	err = repo.Restore(context.Background(), id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestBMIRepository_Purge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := `DELETE FROM bmi_records WHERE deleted_at < ?`
+	before := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := repository.NewBMIRepository(db)
+	total, err := repo.Purge(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+}
@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type CategoryRepository struct {
+	Conn *sql.DB
+}
+
+// NewCategoryRepository will create an object that represent the article.CategoryRepository interface
+func NewCategoryRepository(conn *sql.DB) *CategoryRepository {
+	return &CategoryRepository{conn}
+}
+
+func (m *CategoryRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Category, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Category, 0)
+	for rows.Next() {
+		c := domain.Category{}
+		err = rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.Tag,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+func (m *CategoryRepository) Fetch(ctx context.Context) (res []domain.Category, err error) {
+	query := `SELECT id, name, tag, created_at, updated_at FROM category ORDER BY name`
+	return m.fetch(ctx, query)
+}
+
+func (m *CategoryRepository) GetByID(ctx context.Context, id int64) (res domain.Category, err error) {
+	query := `SELECT id, name, tag, created_at, updated_at FROM category WHERE id = ?`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+// GetByArticleIDs returns the categories of every given article, keyed by article id
+func (m *CategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (res map[int64][]domain.Category, err error) {
+	res = make(map[int64][]domain.Category)
+	if len(articleIDs) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT ac.article_id, c.id, c.name, c.tag, c.created_at, c.updated_at
+  						FROM category c JOIN article_category ac ON ac.category_id = c.id
+  						WHERE ac.article_id IN (` + placeholders(len(articleIDs)) + `) ORDER BY c.name`
+
+	args := make([]interface{}, 0, len(articleIDs))
+	for _, id := range articleIDs {
+		args = append(args, id)
+	}
+
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		articleID := int64(0)
+		c := domain.Category{}
+		err = rows.Scan(
+			&articleID,
+			&c.ID,
+			&c.Name,
+			&c.Tag,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		res[articleID] = append(res[articleID], c)
+	}
+
+	return res, rows.Err()
+}
+
+// AddArticle assigns the category to the article, assigning it twice is a no-op
+func (m *CategoryRepository) AddArticle(ctx context.Context, articleID, categoryID int64) (err error) {
+	query := `INSERT IGNORE article_category SET article_id=? , category_id=?`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, articleID, categoryID)
+	return
+}
+
+func (m *CategoryRepository) RemoveArticle(ctx context.Context, articleID, categoryID int64) (err error) {
+	query := `DELETE FROM article_category WHERE article_id = ? AND category_id = ?`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, articleID, categoryID)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		err = domain.ErrNotFound
+	}
+
+	return
+}
+
+// placeholders builds the "?, ?, ?" list used by IN clauses
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,108 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	repository "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
+)
+
+func TestFetchCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "tag", "created_at", "updated_at"}).
+		AddRow(1, "Golang", "golang", time.Now(), time.Now()).
+		AddRow(2, "Makanan", "makanan", time.Now(), time.Now())
+
+	query := "SELECT id, name, tag, created_at, updated_at FROM category ORDER BY name"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	c := repository.NewCategoryRepository(db)
+
+	list, err := c.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestGetCategoryByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "tag", "created_at", "updated_at"})
+
+	query := "SELECT id, name, tag, created_at, updated_at FROM category WHERE id = \\?"
+
+	mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(rows)
+	c := repository.NewCategoryRepository(db)
+
+	_, err = c.GetByID(context.TODO(), 7)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetCategoryByArticleIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"article_id", "id", "name", "tag", "created_at", "updated_at"}).
+		AddRow(1, 1, "Golang", "golang", time.Now(), time.Now()).
+		AddRow(1, 2, "Makanan", "makanan", time.Now(), time.Now()).
+		AddRow(3, 1, "Golang", "golang", time.Now(), time.Now())
+
+	query := "SELECT ac.article_id, c.id, c.name, c.tag, c.created_at, c.updated_at FROM category c " +
+		"JOIN article_category ac ON ac.category_id = c.id WHERE ac.article_id IN \\(\\?, \\?, \\?\\) ORDER BY c.name"
+
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(2), int64(3)).WillReturnRows(rows)
+	c := repository.NewCategoryRepository(db)
+
+	res, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, res[1], 2)
+	assert.Len(t, res[2], 0)
+	assert.Len(t, res[3], 1)
+}
+
+func TestAddArticleCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT IGNORE article_category SET article_id=\\? , category_id=\\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(int64(12), int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c := repository.NewCategoryRepository(db)
+
+	err = c.AddArticle(context.TODO(), 12, 2)
+	assert.NoError(t, err)
+}
+
+func TestRemoveArticleCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM article_category WHERE article_id = \\? AND category_id = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(int64(12), int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c := repository.NewCategoryRepository(db)
+
+	err = c.RemoveArticle(context.TODO(), 12, 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
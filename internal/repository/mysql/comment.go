@@ -0,0 +1,161 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+)
+
+// commentColumns are read by every comment query, the reply count only covers the approved replies
+const commentColumns = `comment.id, comment.article_id, comment.parent_id, comment.author_name, comment.content,
+  						comment.status, comment.edit_token_hash, comment.updated_at, comment.created_at,
+  						(SELECT COUNT(*) FROM comment reply WHERE reply.parent_id = comment.id AND reply.status = 'approved')`
+
+type CommentRepository struct {
+	Conn    *sql.DB
+	Cursors *repository.CursorCodec
+}
+
+// NewCommentRepository will create an object that represent the comment.CommentRepository interface
+func NewCommentRepository(conn *sql.DB, cursors *repository.CursorCodec) *CommentRepository {
+	return &CommentRepository{conn, cursors}
+}
+
+func (m *CommentRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Comment, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Comment, 0)
+	for rows.Next() {
+		c := domain.Comment{}
+		err = rows.Scan(
+			&c.ID,
+			&c.ArticleID,
+			&c.ParentID,
+			&c.AuthorName,
+			&c.Content,
+			&c.Status,
+			&c.EditTokenHash,
+			&c.UpdatedAt,
+			&c.CreatedAt,
+			&c.ReplyCount,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+// commentSorts are the columns the comments can be sorted by
+var commentSorts = map[string]sortKey[domain.Comment]{
+	defaultSort: createdAtKey("comment", func(c domain.Comment) time.Time {
+		return c.CreatedAt
+	}),
+}
+
+// Fetch pages through the comments matching the query with a keyset on (created_at, id)
+func (m *CommentRepository) Fetch(ctx context.Context, q domain.CommentQuery) (res []domain.Comment, page domain.Page, err error) {
+	query := `SELECT ` + commentColumns + ` FROM comment`
+
+	var conds, filter []string
+	var args []interface{}
+	if q.ArticleID != 0 {
+		conds = append(conds, "comment.article_id = ?")
+		args = append(args, q.ArticleID)
+		filter = append(filter, "article", strconv.FormatInt(q.ArticleID, 10))
+	}
+	if q.ParentID != nil {
+		conds = append(conds, "comment.parent_id = ?")
+		args = append(args, *q.ParentID)
+		filter = append(filter, "parent", strconv.FormatInt(*q.ParentID, 10))
+	}
+	if q.Status != "" {
+		conds = append(conds, "comment.status = ?")
+		args = append(args, q.Status)
+		filter = append(filter, "status", string(q.Status))
+	}
+
+	pager := keysetPager[domain.Comment]{
+		cursors: m.Cursors,
+		table:   "comment",
+		fetch:   m.fetch,
+		id: func(c domain.Comment) int64 {
+			return c.ID
+		},
+		sorts: commentSorts,
+	}
+	return pager.page(ctx, query, conds, args, repository.FilterFingerprint(filter...),
+		pageQuery{Cursor: q.Cursor, Num: q.Num, Order: q.Order})
+}
+
+func (m *CommentRepository) GetByID(ctx context.Context, id int64) (res domain.Comment, err error) {
+	query := `SELECT ` + commentColumns + ` FROM comment WHERE comment.id = ?`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *CommentRepository) Store(ctx context.Context, c *domain.Comment) (err error) {
+	query := `INSERT  comment SET article_id=? , parent_id=? , author_name=? , content=? , status=? , edit_token_hash=? ,
+  						updated_at=? , created_at=?`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, c.ArticleID, c.ParentID, c.AuthorName, c.Content, c.Status, c.EditTokenHash,
+		c.UpdatedAt, c.CreatedAt)
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	c.ID = lastID
+	return
+}
+
+// Update overwrites the content and the status of the comment
+func (m *CommentRepository) Update(ctx context.Context, c *domain.Comment) (err error) {
+	query := `UPDATE comment set content=?, status=?, updated_at=? WHERE id = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, c.Content, c.Status, c.UpdatedAt, c.ID)
+	return
+}
@@ -0,0 +1,138 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+	commentMysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
+)
+
+const commentColumns = "comment.id, comment.article_id, comment.parent_id, comment.author_name, comment.content, " +
+	"comment.status, comment.edit_token_hash, comment.updated_at, comment.created_at, " +
+	"\\(SELECT COUNT\\(\\*\\) FROM comment reply WHERE reply.parent_id = comment.id AND reply.status = 'approved'\\)"
+
+var commentRowColumns = []string{"id", "article_id", "parent_id", "author_name", "content", "status", "edit_token_hash", "updated_at", "created_at", "reply_count"}
+
+func TestFetchComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows(commentRowColumns).
+		AddRow(5, 12, 0, "Iman", "Nice", "approved", "", createdAt, createdAt, 2).
+		AddRow(6, 12, 0, "Ayu", "Agreed", "approved", "", createdAt, createdAt, 0).
+		AddRow(7, 12, 0, "Budi", "Thanks", "approved", "", createdAt, createdAt, 0)
+
+	query := "SELECT " + commentColumns + " FROM comment " +
+		"WHERE comment.article_id = \\? AND comment.parent_id = \\? AND comment.status = \\? " +
+		"AND \\(comment.created_at > \\? OR \\(comment.created_at = \\? AND comment.id > \\?\\)\\) " +
+		"ORDER BY comment.created_at ASC, comment.id ASC LIMIT \\?"
+
+	topLevel := int64(0)
+	filter := repository.FilterFingerprint("article", "12", "parent", "0", "status", "approved")
+	cursor := repository.Cursor{CreatedAt: createdAt, ID: 4, Order: domain.SortAsc, Filter: filter}
+	mock.ExpectQuery(query).
+		WithArgs(int64(12), int64(0), domain.CommentApproved, cursor.CreatedAt, cursor.CreatedAt, cursor.ID, int64(3)).
+		WillReturnRows(rows)
+	c := commentMysqlRepo.NewCommentRepository(db, cursors)
+	list, page, err := c.Fetch(context.TODO(), domain.CommentQuery{
+		Cursor: cursors.EncodeCursor(cursor), Num: 2, ArticleID: 12, ParentID: &topLevel, Status: domain.CommentApproved,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, list, 2)
+	assert.Equal(t, int64(2), list[0].ReplyCount)
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), next.ID)
+	assert.Equal(t, filter, next.Filter)
+	assert.NotEmpty(t, page.Prev)
+}
+
+func TestFetchCommentsCursorOfAnotherArticle(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	c := commentMysqlRepo.NewCommentRepository(db, cursors)
+	cursor := cursors.EncodeCursor(repository.Cursor{
+		ID: 4, Order: domain.SortAsc, Filter: repository.FilterFingerprint("article", "13"),
+	})
+
+	_, _, err = c.Fetch(context.TODO(), domain.CommentQuery{Cursor: cursor, Num: 2, ArticleID: 12})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
+
+func TestGetCommentByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectQuery("SELECT " + commentColumns + " FROM comment WHERE comment.id = \\?").
+		WithArgs(int64(8)).WillReturnRows(sqlmock.NewRows(commentRowColumns))
+	c := commentMysqlRepo.NewCommentRepository(db, cursors)
+
+	_, err = c.GetByID(context.TODO(), 8)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreComment(t *testing.T) {
+	now := time.Now()
+	cm := &domain.Comment{
+		ArticleID:     12,
+		ParentID:      3,
+		AuthorName:    "Iman",
+		Content:       "Nice",
+		Status:        domain.CommentPending,
+		EditToken:     "secret",
+		EditTokenHash: "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  comment SET article_id=\\? , parent_id=\\? , author_name=\\? , content=\\? , status=\\? , edit_token_hash=\\? , " +
+		"updated_at=\\? , created_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	// the edit token itself never reaches the storage
+	prep.ExpectExec().WithArgs(cm.ArticleID, cm.ParentID, cm.AuthorName, cm.Content, cm.Status, cm.EditTokenHash, cm.UpdatedAt, cm.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	c := commentMysqlRepo.NewCommentRepository(db, cursors)
+	err = c.Store(context.TODO(), cm)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), cm.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateComment(t *testing.T) {
+	now := time.Now()
+	cm := &domain.Comment{ID: 7, Content: "Nicer", Status: domain.CommentPending, UpdatedAt: now}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("UPDATE comment set content=\\?, status=\\?, updated_at=\\? WHERE id = \\?")
+	prep.ExpectExec().WithArgs(cm.Content, cm.Status, cm.UpdatedAt, cm.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := commentMysqlRepo.NewCommentRepository(db, cursors)
+	err = c.Update(context.TODO(), cm)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
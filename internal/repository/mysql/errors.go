@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// errDuplicateEntry is the number of the MySQL error raised on a unique index violation
+const errDuplicateEntry = 1062
+
+// mapError translates the MySQL errors the services act on into domain errors. A duplicate entry
+// is reported as the error keyErrors has for the violated unique key, domain.ErrConflict otherwise
+func mapError(err error, keyErrors map[string]error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != errDuplicateEntry {
+		return err
+	}
+	if keyErr, ok := keyErrors[duplicateKey(mysqlErr.Message)]; ok {
+		return fmt.Errorf("%w: %s", keyErr, mysqlErr.Message)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrConflict, mysqlErr.Message)
+}
+
+// duplicateKey reads the name of the unique key out of a duplicate entry message, like
+// "Duplicate entry '0-Hello' for key 'title'". MySQL 8 prefixes the key with its table
+func duplicateKey(message string) string {
+	i := strings.LastIndex(message, " for key '")
+	if i < 0 {
+		return ""
+	}
+	key := strings.TrimSuffix(message[i+len(" for key '"):], "'")
+	if dot := strings.LastIndex(key, "."); dot >= 0 {
+		key = key[dot+1:]
+	}
+	return key
+}
@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+)
+
+// defaultSort is the sort of the listings that don't ask for one
+const defaultSort = "created_at"
+
+// pageQuery holds the paging parameters shared by the listings
+type pageQuery struct {
+	Cursor string
+	Num    int64
+	Order  domain.SortOrder
+	// Sort names one of the pager's sort keys, defaultSort when empty
+	Sort string
+}
+
+// sortKey is a column a listing can be sorted by, the id breaks the ties
+type sortKey[T any] struct {
+	column string
+	// mark records the row's value of the column into the cursor, at reads it back
+	mark func(row T, cursor *repository.Cursor)
+	at   func(cursor repository.Cursor) interface{}
+}
+
+// createdAtKey sorts the rows of table by their creation
+func createdAtKey[T any](table string, createdAt func(T) time.Time) sortKey[T] {
+	return sortKey[T]{
+		column: table + ".created_at",
+		mark: func(row T, cursor *repository.Cursor) {
+			cursor.CreatedAt = createdAt(row)
+		},
+		at: func(cursor repository.Cursor) interface{} {
+			return cursor.CreatedAt
+		},
+	}
+}
+
+// keysetPager pages through the rows of table with a keyset on the sort column and the id, so rows
+// with the same value are neither skipped nor repeated. One extra row is read to know if there is more
+type keysetPager[T any] struct {
+	cursors *repository.CursorCodec
+	table   string
+	fetch   func(ctx context.Context, query string, args ...interface{}) ([]T, error)
+	id      func(T) int64
+	// sorts are the keys the rows can be sorted by, by name. There must be a defaultSort one
+	sorts map[string]sortKey[T]
+}
+
+// page reads one page of the query narrowed down by conds. Cursors made for another filter,
+// order or sort are rejected with domain.ErrBadParamInput, and so are unknown sorts
+func (p keysetPager[T]) page(ctx context.Context, query string, conds []string, args []interface{},
+	filter string, q pageQuery) (res []T, page domain.Page, err error) {
+	if q.Sort == "" && q.Cursor == "" {
+		q.Sort = defaultSort
+	}
+	cursor := repository.Cursor{Order: q.Order, Sort: q.Sort, Filter: filter}
+	if q.Cursor != "" {
+		cursor, err = p.cursors.DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, domain.Page{}, err
+		}
+		if cursor.Sort == "" {
+			cursor.Sort = defaultSort
+		}
+		if cursor.Filter != filter || (q.Order != "" && q.Order != cursor.Order) || (q.Sort != "" && q.Sort != cursor.Sort) {
+			return nil, domain.Page{}, domain.ErrBadParamInput
+		}
+	}
+	if !cursor.Order.IsValid() {
+		cursor.Order = domain.SortAsc
+	}
+	key, ok := p.sorts[cursor.Sort]
+	if !ok {
+		return nil, domain.Page{}, domain.ErrBadParamInput
+	}
+
+	// walking backward through an ascending list is walking forward through a descending one
+	op, dir := ">", "ASC"
+	if (cursor.Order == domain.SortDesc) != cursor.Backward {
+		op, dir = "<", "DESC"
+	}
+
+	id := p.table + ".id"
+	if q.Cursor != "" {
+		conds = append(conds, "("+key.column+" "+op+" ? OR ("+key.column+" = ? AND "+id+" "+op+" ?))")
+		args = append(args, key.at(cursor), key.at(cursor), cursor.ID)
+	}
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY " + key.column + " " + dir + ", " + id + " " + dir + " LIMIT ?"
+	args = append(args, q.Num+1)
+
+	res, err = p.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, domain.Page{}, err
+	}
+
+	hasMore := int64(len(res)) > q.Num
+	if hasMore {
+		res = res[:q.Num]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return res, domain.Page{}, nil
+	}
+
+	first, last := res[0], res[len(res)-1]
+	next := repository.Cursor{ID: p.id(last), Order: cursor.Order, Sort: cursor.Sort, Filter: filter}
+	key.mark(last, &next)
+	prev := repository.Cursor{ID: p.id(first), Order: cursor.Order, Sort: cursor.Sort, Backward: true, Filter: filter}
+	key.mark(first, &prev)
+	switch {
+	case cursor.Backward:
+		page.Next = p.cursors.EncodeCursor(next)
+		if hasMore {
+			page.Prev = p.cursors.EncodeCursor(prev)
+		}
+	default:
+		if hasMore {
+			page.Next = p.cursors.EncodeCursor(next)
+		}
+		if q.Cursor != "" {
+			page.Prev = p.cursors.EncodeCursor(prev)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+)
+
+// snippetWidth is the length in bytes of the excerpts shown with the search hits
+const snippetWidth = 160
+
+type SearchRepository struct {
+	Conn    *sql.DB
+	Cursors *repository.CursorCodec
+}
+
+// NewSearchRepository will create an object that represent the article.SearchRepository interface
+func NewSearchRepository(conn *sql.DB, cursors *repository.CursorCodec) *SearchRepository {
+	return &SearchRepository{conn, cursors}
+}
+
+// Search looks the terms up in the FULLTEXT index over the title and content of the published articles.
+// Hits are ranked by relevance and paged forward with a keyset on (score, id)
+func (m *SearchRepository) Search(ctx context.Context, q domain.SearchQuery) (res []domain.SearchHit, page domain.Page, err error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, slug,
+  						MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+  						FROM article WHERE MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND status = ? AND deleted_at IS NULL`
+	args := []interface{}{q.Terms, q.Terms, domain.StatusPublished}
+
+	filter := repository.SearchFingerprint(q.Terms)
+	if q.Cursor != "" {
+		cursor, err := m.Cursors.DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, domain.Page{}, err
+		}
+		if cursor.Filter != filter {
+			return nil, domain.Page{}, domain.ErrBadParamInput
+		}
+		query += ` HAVING score < ? OR (score = ? AND id > ?)`
+		args = append(args, cursor.Score, cursor.Score, cursor.ID)
+	}
+	query += ` ORDER BY score DESC, id ASC LIMIT ?`
+	args = append(args, q.Num+1)
+
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, domain.Page{}, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	res = make([]domain.SearchHit, 0)
+	for rows.Next() {
+		hit := domain.SearchHit{}
+		err = rows.Scan(
+			&hit.Article.ID,
+			&hit.Article.Title,
+			&hit.Article.Content,
+			&hit.Article.Author.ID,
+			&hit.Article.UpdatedAt,
+			&hit.Article.CreatedAt,
+			&hit.Article.Slug,
+			&hit.Score,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, domain.Page{}, err
+		}
+		hit.Article.Status = domain.StatusPublished
+		hit.Snippet = repository.Highlight(hit.Article.Content, q.Terms, snippetWidth)
+		res = append(res, hit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, domain.Page{}, err
+	}
+
+	if int64(len(res)) > q.Num {
+		res = res[:q.Num]
+		last := res[len(res)-1]
+		page.Next = m.Cursors.EncodeCursor(repository.Cursor{Score: last.Score, ID: last.Article.ID, Order: domain.SortDesc, Filter: filter})
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/repository"
+	articleMysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
+)
+
+func TestSearchArticle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "slug", "score"}).
+		AddRow(2, "Makan Ikan", "<p>ikan bakar</p>", 1, createdAt, createdAt, "makan-ikan", 0.9).
+		AddRow(3, "Makan Sayur", "sayur asem", 1, createdAt, createdAt, "makan-sayur", 0.5)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, slug, " +
+		"MATCH\\(title, content\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) AS score " +
+		"FROM article WHERE MATCH\\(title, content\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) AND status = \\? AND deleted_at IS NULL " +
+		"HAVING score < \\? OR \\(score = \\? AND id > \\?\\) ORDER BY score DESC, id ASC LIMIT \\?"
+
+	terms := "makan ikan"
+	cursor := repository.Cursor{Score: 1.2, ID: 1, Order: domain.SortDesc, Filter: repository.SearchFingerprint(terms)}
+	mock.ExpectQuery(query).WithArgs(terms, terms, domain.StatusPublished, 1.2, 1.2, int64(1), int64(2)).WillReturnRows(rows)
+
+	s := articleMysqlRepo.NewSearchRepository(db, cursors)
+	hits, page, err := s.Search(context.TODO(), domain.SearchQuery{Terms: terms, Cursor: cursors.EncodeCursor(cursor), Num: 1})
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, int64(2), hits[0].Article.ID)
+	assert.Equal(t, "<mark>ikan</mark> bakar", hits[0].Snippet)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	next, err := cursors.DecodeCursor(page.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.9, next.Score)
+	assert.Equal(t, int64(2), next.ID)
+	assert.Empty(t, page.Prev)
+}
+
+func TestSearchArticleCursorOfAnotherSearch(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	cursor := repository.Cursor{Score: 1.2, ID: 1, Order: domain.SortDesc, Filter: repository.SearchFingerprint("makan")}
+	s := articleMysqlRepo.NewSearchRepository(db, cursors)
+	_, _, err = s.Search(context.TODO(), domain.SearchQuery{Terms: "ikan", Cursor: cursors.EncodeCursor(cursor), Num: 1})
+	assert.ErrorIs(t, err, domain.ErrBadParamInput)
+}
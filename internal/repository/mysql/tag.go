@@ -0,0 +1,227 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// tagQuery reads the tags along with how many published articles carry them, the trashed ones left out
+const tagQuery = `SELECT t.id, t.name, COUNT(a.id) AS uses
+  						FROM tag t LEFT JOIN article_tag at ON at.tag_id = t.id
+  						LEFT JOIN article a ON a.id = at.article_id AND a.deleted_at IS NULL AND a.status = 'published'`
+
+type TagRepository struct {
+	Conn *sql.DB
+}
+
+// NewTagRepository will create an object that represent the article.TagRepository interface
+func NewTagRepository(conn *sql.DB) *TagRepository {
+	return &TagRepository{conn}
+}
+
+func (m *TagRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Tag, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Tag, 0)
+	for rows.Next() {
+		t := domain.Tag{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.Usage,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// Suggest returns the num tags starting with the prefix, the most used first
+func (m *TagRepository) Suggest(ctx context.Context, prefix string, num int64) (res []domain.Tag, err error) {
+	query := tagQuery + ` WHERE t.name LIKE ? GROUP BY t.id, t.name ORDER BY uses DESC, t.name LIMIT ?`
+	return m.fetch(ctx, query, escapeLike(prefix)+"%", num)
+}
+
+func (m *TagRepository) GetByID(ctx context.Context, id int64) (res domain.Tag, err error) {
+	return m.getOne(ctx, tagQuery+` WHERE t.id = ? GROUP BY t.id, t.name`, id)
+}
+
+func (m *TagRepository) GetByName(ctx context.Context, name string) (res domain.Tag, err error) {
+	return m.getOne(ctx, tagQuery+` WHERE t.name = ? GROUP BY t.id, t.name`, name)
+}
+
+func (m *TagRepository) getOne(ctx context.Context, query string, args ...interface{}) (res domain.Tag, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+// GetByArticleIDs returns the tag names of every given article, keyed by article id
+func (m *TagRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (res map[int64][]string, err error) {
+	res = make(map[int64][]string)
+	if len(articleIDs) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT at.article_id, t.name FROM tag t JOIN article_tag at ON at.tag_id = t.id
+  						WHERE at.article_id IN (` + placeholders(len(articleIDs)) + `) ORDER BY t.name`
+
+	args := make([]interface{}, 0, len(articleIDs))
+	for _, id := range articleIDs {
+		args = append(args, id)
+	}
+
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		articleID := int64(0)
+		name := ""
+		err = rows.Scan(&articleID, &name)
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		res[articleID] = append(res[articleID], name)
+	}
+
+	return res, rows.Err()
+}
+
+// SetArticleTags replaces the tags of the article with the given names, the tags that
+// don't exist yet are made up on the way
+func (m *TagRepository) SetArticleTags(ctx context.Context, articleID int64, names []string) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer rollbackOnError(tx, &err)
+
+	if len(names) == 0 {
+		_, err = tx.ExecContext(ctx, `DELETE FROM article_tag WHERE article_id = ?`, articleID)
+		if err != nil {
+			return
+		}
+		err = tx.Commit()
+		return
+	}
+
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	insert := `INSERT IGNORE tag (name) VALUES ` + strings.TrimSuffix(strings.Repeat("(?), ", len(names)), ", ")
+	_, err = tx.ExecContext(ctx, insert, args...)
+	if err != nil {
+		return
+	}
+
+	named := `SELECT id FROM tag WHERE name IN (` + placeholders(len(names)) + `)`
+	_, err = tx.ExecContext(ctx, `DELETE FROM article_tag WHERE article_id = ? AND tag_id NOT IN (`+named+`)`,
+		append([]interface{}{articleID}, args...)...)
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, `INSERT IGNORE article_tag (article_id, tag_id) SELECT ?, id FROM tag WHERE name IN (`+placeholders(len(names))+`)`,
+		append([]interface{}{articleID}, args...)...)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// Rename renames the tag, a name another tag already has is reported as domain.ErrConflict
+func (m *TagRepository) Rename(ctx context.Context, id int64, name string) (err error) {
+	query := `UPDATE tag SET name = ? WHERE id = ?`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, name, id)
+	return mapError(err, nil)
+}
+
+// Merge moves the articles of the fromID tag over to the intoID tag and removes the fromID tag,
+// all at once so no article is ever left without one of them
+func (m *TagRepository) Merge(ctx context.Context, fromID, intoID int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer rollbackOnError(tx, &err)
+
+	// the articles carrying both tags already have the intoID one
+	_, err = tx.ExecContext(ctx, `INSERT IGNORE article_tag (article_id, tag_id) SELECT article_id, ? FROM article_tag WHERE tag_id = ?`,
+		intoID, fromID)
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM article_tag WHERE tag_id = ?`, fromID)
+	if err != nil {
+		return
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM tag WHERE id = ?`, fromID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrNotFound
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
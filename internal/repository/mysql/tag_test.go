@@ -0,0 +1,114 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	tagMysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
+)
+
+const tagQuery = "SELECT t.id, t.name, COUNT\\(a.id\\) AS uses FROM tag t LEFT JOIN article_tag at ON at.tag_id = t.id " +
+	"LEFT JOIN article a ON a.id = at.article_id AND a.deleted_at IS NULL AND a.status = 'published'"
+
+func TestSuggestTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "uses"}).
+		AddRow(3, "go_lang", 12).
+		AddRow(1, "go_lang-tips", 4)
+
+	query := tagQuery + " WHERE t.name LIKE \\? GROUP BY t.id, t.name ORDER BY uses DESC, t.name LIMIT \\?"
+	mock.ExpectQuery(query).WithArgs(`go\_%`, int64(5)).WillReturnRows(rows)
+	r := tagMysqlRepo.NewTagRepository(db)
+
+	list, err := r.Suggest(context.TODO(), "go_", 5)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []domain.Tag{{ID: 3, Name: "go_lang", Usage: 12}, {ID: 1, Name: "go_lang-tips", Usage: 4}}, list)
+}
+
+func TestGetTagByName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectQuery(tagQuery + " WHERE t.name = \\? GROUP BY t.id, t.name").WithArgs("go").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "uses"}))
+	r := tagMysqlRepo.NewTagRepository(db)
+
+	_, err = r.GetByName(context.TODO(), "go")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTagsByArticleIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"article_id", "name"}).
+		AddRow(1, "go").
+		AddRow(2, "go").
+		AddRow(1, "mysql")
+
+	query := "SELECT at.article_id, t.name FROM tag t JOIN article_tag at ON at.tag_id = t.id " +
+		"WHERE at.article_id IN \\(\\?, \\?\\) ORDER BY t.name"
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(2)).WillReturnRows(rows)
+	r := tagMysqlRepo.NewTagRepository(db)
+
+	res, err := r.GetByArticleIDs(context.TODO(), []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64][]string{1: {"go", "mysql"}, 2: {"go"}}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetArticleTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	named := "\\(SELECT id FROM tag WHERE name IN \\(\\?, \\?\\)\\)"
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT IGNORE tag \\(name\\) VALUES \\(\\?\\), \\(\\?\\)").WithArgs("go", "mysql").
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec("DELETE FROM article_tag WHERE article_id = \\? AND tag_id NOT IN "+named).WithArgs(int64(12), "go", "mysql").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE article_tag \\(article_id, tag_id\\) SELECT \\?, id FROM tag WHERE name IN \\(\\?, \\?\\)").
+		WithArgs(int64(12), "go", "mysql").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	r := tagMysqlRepo.NewTagRepository(db)
+
+	err = r.SetArticleTags(context.TODO(), 12, []string{"go", "mysql"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT IGNORE article_tag \\(article_id, tag_id\\) SELECT article_id, \\? FROM article_tag WHERE tag_id = \\?").
+		WithArgs(int64(3), int64(7)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM article_tag WHERE tag_id = \\?").WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM tag WHERE id = \\?").WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	r := tagMysqlRepo.NewTagRepository(db)
+
+	// the tag was merged away in between
+	err = r.Merge(context.TODO(), 7, 3)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
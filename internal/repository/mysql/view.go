@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// viewBucket is the span the views of an article are added up over, the trending ranking
+// can't tell apart the views of a bucket
+const viewBucket = time.Hour
+
+type ViewRepository struct {
+	Conn *sql.DB
+}
+
+// NewViewRepository will create an object that represent the article.ViewRepository interface
+func NewViewRepository(conn *sql.DB) *ViewRepository {
+	return &ViewRepository{conn}
+}
+
+// AddViews adds the views of every article to the bucket of the given time with a single query
+func (m *ViewRepository) AddViews(ctx context.Context, at time.Time, counts map[int64]int64) (err error) {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	bucket := at.Truncate(viewBucket)
+	values := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, 3*len(ids))
+	for _, id := range ids {
+		values = append(values, "(?, ?, ?)")
+		args = append(args, id, bucket, counts[id])
+	}
+	query := `INSERT INTO article_view (article_id, bucket, views) VALUES ` + strings.Join(values, ", ") +
+		` ON DUPLICATE KEY UPDATE views = views + VALUES(views)`
+
+	_, err = m.Conn.ExecContext(ctx, query, args...)
+	return
+}
+
+// Trending ranks the published articles by their views since the bucket of the given time, each bucket
+// of views is halved for every halfLife it is older than now. Only the ids of the articles are set
+func (m *ViewRepository) Trending(ctx context.Context, since, now time.Time, halfLife time.Duration,
+	num int64) (res []domain.TrendingArticle, err error) {
+	query := `SELECT v.article_id, SUM(v.views),
+  						SUM(v.views * POW(0.5, TIMESTAMPDIFF(SECOND, v.bucket, ?) / ?)) AS score
+  						FROM article_view v JOIN article ON article.id = v.article_id
+  						WHERE v.bucket >= ? AND article.status = ? AND article.deleted_at IS NULL
+  						GROUP BY v.article_id ORDER BY score DESC, v.article_id LIMIT ?`
+
+	rows, err := m.Conn.QueryContext(ctx, query, now, halfLife.Seconds(), since.Truncate(viewBucket),
+		domain.StatusPublished, num)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	res = make([]domain.TrendingArticle, 0)
+	for rows.Next() {
+		t := domain.TrendingArticle{}
+		err = rows.Scan(
+			&t.Article.ID,
+			&t.Views,
+			&t.Score,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	viewMysqlRepo "github.com/bxcodec/go-clean-arch/internal/repository/mysql"
+)
+
+func TestAddViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	bucket := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	query := "INSERT INTO article_view \\(article_id, bucket, views\\) VALUES \\(\\?, \\?, \\?\\), \\(\\?, \\?, \\?\\) " +
+		"ON DUPLICATE KEY UPDATE views = views \\+ VALUES\\(views\\)"
+	mock.ExpectExec(query).WithArgs(int64(1), bucket, int64(4), int64(7), bucket, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	r := viewMysqlRepo.NewViewRepository(db)
+
+	err = r.AddViews(context.TODO(), at, map[int64]int64{7: 1, 1: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"article_id", "views", "score"}).
+		AddRow(7, 40, 31.5).
+		AddRow(1, 90, 12.25)
+
+	query := "SELECT v.article_id, SUM\\(v.views\\), SUM\\(v.views \\* POW\\(0.5, TIMESTAMPDIFF\\(SECOND, v.bucket, \\?\\) / \\?\\)\\) AS score " +
+		"FROM article_view v JOIN article ON article.id = v.article_id " +
+		"WHERE v.bucket >= \\? AND article.status = \\? AND article.deleted_at IS NULL " +
+		"GROUP BY v.article_id ORDER BY score DESC, v.article_id LIMIT \\?"
+	mock.ExpectQuery(query).
+		WithArgs(now, float64(6*3600), time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), domain.StatusPublished, int64(10)).
+		WillReturnRows(rows)
+	r := viewMysqlRepo.NewViewRepository(db)
+
+	list, err := r.Trending(context.TODO(), now.Add(-24*time.Hour), now, 6*time.Hour, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []domain.TrendingArticle{
+		{Article: domain.Article{ID: 7}, Views: 40, Score: 31.5},
+		{Article: domain.Article{ID: 1}, Views: 90, Score: 12.25},
+	}, list)
+}
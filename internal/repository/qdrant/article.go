@@ -0,0 +1,127 @@
+package qdrantrepo
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/qdrant/go-client/qdrant"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// Embedder turns a text into the vector it's indexed and searched by
+type Embedder interface {
+	Dimension() int
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// ArticleRepository indexes the articles in their own collection, each point is an article
+// keyed by its id and embedded from its title and content
+type ArticleRepository struct {
+	client         *client.Client
+	collectionName string
+	embedder       Embedder
+}
+
+func NewArticleRepository(endpoint, apiKey, collectionName string, embedder Embedder) (*ArticleRepository, error) {
+	qdrantClient, err := client.NewClient(&client.Config{
+		Host:   endpoint,
+		APIKey: apiKey,
+		UseTLS: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Qdrant client: %w", err)
+	}
+
+	return &ArticleRepository{
+		client:         qdrantClient,
+		collectionName: collectionName,
+		embedder:       embedder,
+	}, nil
+}
+
+// CreateCollection creates the collection sized for the embedder, unless it already exists
+func (r *ArticleRepository) CreateCollection(ctx context.Context) error {
+	exists, err := r.client.CollectionExists(ctx, r.collectionName)
+	if err != nil {
+		return fmt.Errorf("failed to check collection: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	err = r.client.CreateCollection(ctx, &client.CreateCollection{
+		CollectionName: r.collectionName,
+		VectorsConfig: client.NewVectorsConfig(&client.VectorParams{
+			Size:     uint64(r.embedder.Dimension()),
+			Distance: client.Distance_Cosine,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	return nil
+}
+
+// Index embeds the article and upserts it
+func (r *ArticleRepository) Index(ctx context.Context, ar domain.Article) error {
+	vector, err := r.embedder.Embed(ctx, ar.Title+"\n"+ar.Content)
+	if err != nil {
+		return fmt.Errorf("failed to embed article: %w", err)
+	}
+
+	point := &client.PointStruct{
+		Id:      client.NewIDNum(uint64(ar.ID)),
+		Vectors: client.NewVectors(vector...),
+		Payload: client.NewValueMap(map[string]any{
+			"title": ar.Title,
+		}),
+	}
+
+	_, err = r.client.Upsert(ctx, &client.UpsertPoints{
+		CollectionName: r.collectionName,
+		Points:         []*client.PointStruct{point},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert point: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the article's point
+func (r *ArticleRepository) Remove(ctx context.Context, id int64) error {
+	_, err := r.client.Delete(ctx, &client.DeletePoints{
+		CollectionName: r.collectionName,
+		Points:         client.NewPointsSelector(client.NewIDNum(uint64(id))),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete point: %w", err)
+	}
+	return nil
+}
+
+// Search returns the limit articles closest to the text, only their id is set
+func (r *ArticleRepository) Search(ctx context.Context, text string, limit int64) ([]domain.SearchHit, error) {
+	vector, err := r.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.client.Query(ctx, &client.QueryPoints{
+		CollectionName: r.collectionName,
+		Query:          client.NewQuery(vector...),
+		Limit:          client.PtrOf(uint64(limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query points: %w", err)
+	}
+
+	hits := make([]domain.SearchHit, 0, len(response))
+	for _, point := range response {
+		hits = append(hits, domain.SearchHit{
+			Article: domain.Article{ID: int64(point.GetId().GetNum())},
+			Score:   float64(point.GetScore()),
+		})
+	}
+	return hits, nil
+}
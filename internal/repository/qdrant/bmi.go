@@ -48,9 +48,13 @@ func (r *BMIRepository) Store(ctx context.Context, bmi *domain.BMI) error {
 	vector := client.NewVectors(float32(bmi.Height), float32(bmi.Weight), float32(bmi.Value))
 
 	payload := client.NewValueMap(map[string]any{
-		"category":   bmi.Category,
-		"risk":       bmi.Risk,
-		"created_at": bmi.CreatedAt.Format(time.RFC3339),
+		"category":      bmi.Category,
+		"risk":          bmi.Risk,
+		"scheme":        bmi.Scheme,
+		"category_code": bmi.CategoryCode,
+		"risk_code":     bmi.RiskCode,
+		"language":      bmi.Language,
+		"created_at":    bmi.CreatedAt.Format(time.RFC3339),
 	})
 
 	point := &client.PointStruct{
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// SearchTerms splits the search into its distinct lower cased words
+func SearchTerms(terms string) []string {
+	words := strings.FieldsFunc(strings.ToLower(terms), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	res := make([]string, 0, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			res = append(res, word)
+		}
+	}
+	return res
+}
+
+// SearchFingerprint is the filter fingerprint of a search, see FilterFingerprint
+func SearchFingerprint(terms string) string {
+	return FilterFingerprint(append([]string{"search"}, SearchTerms(terms)...)...)
+}
+
+// Highlight cuts an excerpt of about width bytes around the first word of the search found in the text.
+// The tags of the text are dropped and its entities unescaped, the excerpt is HTML escaped again and the
+// words found are wrapped in <mark>
+func Highlight(text, terms string, width int) string {
+	plain := strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(text, " "))), " ")
+
+	words := SearchTerms(terms)
+	if len(words) == 0 {
+		return html.EscapeString(excerpt(plain, 0, width))
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	matcher := regexp.MustCompile(`(?i)` + strings.Join(words, "|"))
+
+	start := 0
+	if loc := matcher.FindStringIndex(plain); loc != nil {
+		start = loc[0] - width/4
+	}
+	plain = excerpt(plain, start, width)
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range matcher.FindAllStringIndex(plain, -1) {
+		b.WriteString(html.EscapeString(plain[last:loc[0]]))
+		b.WriteString("<mark>" + html.EscapeString(plain[loc[0]:loc[1]]) + "</mark>")
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(plain[last:]))
+	return b.String()
+}
+
+// excerpt cuts about width bytes of the text from start without splitting words,
+// an ellipsis marks the text left out on each side
+func excerpt(text string, start, width int) string {
+	switch {
+	case start <= 0:
+		start = 0
+	case start >= len(text)-width:
+		// a match close to the end still gets a full excerpt
+		start = max(0, len(text)-width)
+		if start > 0 && text[start-1] != ' ' {
+			start += strings.IndexByte(text[start:], ' ') + 1
+		}
+	default:
+		start = strings.LastIndexByte(text[:start], ' ') + 1
+	}
+	for start < len(text) && !utf8.RuneStart(text[start]) {
+		start++
+	}
+
+	end := min(len(text), start+width)
+	if end < len(text) && text[end] != ' ' {
+		if i := strings.LastIndexByte(text[start:end], ' '); i > 0 {
+			end = start + i
+		}
+		for end > start && !utf8.RuneStart(text[end]) {
+			end--
+		}
+	}
+
+	res := text[start:end]
+	if start > 0 {
+		res = "…" + res
+	}
+	if end < len(text) {
+		res += "…"
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"makan", "ayam"}, SearchTerms("  Makan, ayam! makan "))
+	assert.Equal(t, SearchFingerprint("Makan ayam"), SearchFingerprint("makan  AYAM"))
+	assert.NotEqual(t, SearchFingerprint("makan ayam"), SearchFingerprint("makan ikan"))
+}
+
+func TestHighlight(t *testing.T) {
+	tests := map[string]struct {
+		text  string
+		terms string
+		width int
+		want  string
+	}{
+		"marks-every-word": {
+			text:  "<p>Makan ayam & makan ikan</p>",
+			terms: "makan IKAN",
+			width: 100,
+			want:  "<mark>Makan</mark> ayam &amp; <mark>makan</mark> <mark>ikan</mark>",
+		},
+		"unescapes-the-entities": {
+			text:  "<p>Fish &amp; chips &lt;3&nbsp;caf&eacute;</p>",
+			terms: "café",
+			width: 100,
+			want:  "Fish &amp; chips &lt;3 <mark>café</mark>",
+		},
+		"cuts-around-the-match": {
+			text:  "one two three four five six seven eight nine ten",
+			terms: "six",
+			width: 20,
+			want:  "…five <mark>six</mark> seven eight…",
+		},
+		"match-near-the-end": {
+			text:  "one two three four five six seven eight nine ten",
+			terms: "ten",
+			width: 16,
+			want:  "…eight nine <mark>ten</mark>",
+		},
+		"no-match": {
+			text:  "one two three four five",
+			terms: "six",
+			width: 10,
+			want:  "one two…",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Highlight(tc.text, tc.terms, tc.width))
+		})
+	}
+}
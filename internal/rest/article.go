@@ -2,8 +2,15 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -21,12 +28,20 @@ type ResponseError struct {
 //
 //go:generate mockery --name ArticleService
 type ArticleService interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]domain.Article, string, error)
+	Fetch(ctx context.Context, q domain.ArticleQuery) ([]domain.Article, domain.Page, error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
 	Update(ctx context.Context, ar *domain.Article) error
 	GetByTitle(ctx context.Context, title string) (domain.Article, error)
+	GetBySlug(ctx context.Context, slug string) (domain.Article, error)
 	Store(context.Context, *domain.Article) error
 	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) (domain.Article, error)
+	Transition(ctx context.Context, id int64, to domain.ArticleStatus, publishAt *time.Time) (domain.Article, error)
+	FetchTrash(ctx context.Context) ([]domain.Article, error)
+	Search(ctx context.Context, q domain.SearchQuery) ([]domain.SearchHit, domain.Page, error)
+	SemanticSearch(ctx context.Context, text string, num int64) ([]domain.SearchHit, error)
+	RecordView(ctx context.Context, ar domain.Article, client string)
+	Trending(ctx context.Context, window time.Duration, num int64) ([]domain.TrendingArticle, error)
 }
 
 // ArticleHandler  represent the httphandler for article
@@ -34,7 +49,16 @@ type ArticleHandler struct {
 	Service ArticleService
 }
 
-const defaultNum = 10
+const (
+	defaultNum = 10
+
+	defaultTrendingWindow = 24 * time.Hour
+
+	viewFull    = "full"
+	viewSummary = "summary"
+
+	mimeApplicationMergePatchJSON = "application/merge-patch+json"
+)
 
 // NewArticleHandler will initialize the articles/ resources endpoint
 func NewArticleHandler(e *echo.Echo, svc ArticleService) {
@@ -42,34 +66,265 @@ func NewArticleHandler(e *echo.Echo, svc ArticleService) {
 		Service: svc,
 	}
 	e.GET("/articles", handler.FetchArticle)
+	e.GET("/articles/search", handler.Search)
+	e.GET("/articles/semantic", handler.SemanticSearch)
+	e.GET("/articles/trending", handler.Trending)
 	e.POST("/articles", handler.Store)
+	e.GET("/articles/by-slug/:slug", handler.GetBySlug)
 	e.GET("/articles/:id", handler.GetByID)
+	e.PUT("/articles/:id", handler.Update)
+	e.PATCH("/articles/:id", handler.Patch)
 	e.DELETE("/articles/:id", handler.Delete)
+	e.POST("/articles/:id/restore", handler.Restore)
+	e.POST("/articles/:id/status", handler.Transition)
+	e.GET("/admin/articles", handler.FetchAll)
+	e.GET("/admin/articles/trash", handler.FetchTrash)
+	e.GET("/admin/articles/by-slug/:slug", handler.GetAnyBySlug)
+	e.GET("/admin/articles/:id", handler.GetAnyByID)
 }
 
-// FetchArticle will fetch the article based on given params
+// StatusRequest represent the request body moving an article through the workflow
+type StatusRequest struct {
+	Status    domain.ArticleStatus `json:"status" validate:"required"`
+	PublishAt *time.Time           `json:"publish_at,omitempty"`
+}
+
+// FetchArticle will fetch the published articles based on given params
 func (a *ArticleHandler) FetchArticle(c echo.Context) error {
+	q, err := articleQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	q.Status = domain.StatusPublished
 
-	numS := c.QueryParam("num")
-	num, err := strconv.Atoi(numS)
-	if err != nil || num == 0 {
-		num = defaultNum
+	return a.fetch(c, q)
+}
+
+// FetchAll will fetch the articles whatever their status, the status param narrows them down to one
+func (a *ArticleHandler) FetchAll(c echo.Context) error {
+	q, err := articleQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	q.Status = domain.ArticleStatus(c.QueryParam("status"))
+	if q.Status != "" && !q.Status.IsValid() {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "unknown status " + strconv.Quote(string(q.Status))})
+	}
+
+	return a.fetch(c, q)
+}
+
+// fetch serves one page of the article listing, the view param set to summary leaves the content
+// out of the articles so only their excerpt is sent
+func (a *ArticleHandler) fetch(c echo.Context, q domain.ArticleQuery) error {
+	view := c.QueryParam("view")
+	if view != "" && view != viewFull && view != viewSummary {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "view must be full or summary"})
 	}
 
-	cursor := c.QueryParam("cursor")
 	ctx := c.Request().Context()
 
-	listAr, nextCursor, err := a.Service.Fetch(ctx, cursor, int64(num))
-	if err != nil {
+	listAr, page, err := a.Service.Fetch(ctx, q)
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
 		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
 
-	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	if view == viewSummary {
+		for i := range listAr {
+			listAr[i].Content = ""
+		}
+	}
+
+	setPageHeaders(c, page)
 	return c.JSON(http.StatusOK, listAr)
 }
 
-// GetByID will get article by given id
+// Search will fetch the articles matching the q param, the most relevant first
+func (a *ArticleHandler) Search(c echo.Context) error {
+	q := domain.SearchQuery{
+		Terms:  c.QueryParam("q"),
+		Cursor: c.QueryParam("cursor"),
+		Num:    pageSize(c),
+	}
+	if strings.TrimSpace(q.Terms) == "" {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "q is required"})
+	}
+
+	ctx := c.Request().Context()
+
+	hits, page, err := a.Service.Search(ctx, q)
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	setPageHeaders(c, page)
+	return c.JSON(http.StatusOK, hits)
+}
+
+// SemanticSearch will fetch the articles closest in meaning to the q param, with their similarity
+func (a *ArticleHandler) SemanticSearch(c echo.Context) error {
+	text := c.QueryParam("q")
+	if strings.TrimSpace(text) == "" {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "q is required"})
+	}
+
+	ctx := c.Request().Context()
+
+	hits, err := a.Service.SemanticSearch(ctx, text, pageSize(c))
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, hits)
+}
+
+// Trending will fetch the articles read the most over the window param, 24h by default and from 1h to 30 days
+func (a *ArticleHandler) Trending(c echo.Context) error {
+	window := defaultTrendingWindow
+	if value := c.QueryParam("window"); value != "" {
+		var err error
+		window, err = time.ParseDuration(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ResponseError{Message: "window must be a duration such as 24h"})
+		}
+	}
+
+	ctx := c.Request().Context()
+
+	res, err := a.Service.Trending(ctx, window, pageSize(c))
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// viewer tells the readers apart to count their views once, by their address and browser
+func viewer(c echo.Context) string {
+	return c.RealIP() + " " + c.Request().UserAgent()
+}
+
+// pageSize reads the num param, falling back to the default page size
+func pageSize(c echo.Context) int64 {
+	num, err := strconv.Atoi(c.QueryParam("num"))
+	if err != nil || num <= 0 {
+		num = defaultNum
+	}
+	return int64(num)
+}
+
+// articleQuery reads the num, cursor, order and sort paging params, along with the filters.
+// The dates are RFC3339 times or plain dates, a plain date as the end of a range includes the whole day
+func articleQuery(c echo.Context) (q domain.ArticleQuery, err error) {
+	q = domain.ArticleQuery{
+		Cursor:      c.QueryParam("cursor"),
+		Num:         pageSize(c),
+		Order:       domain.SortOrder(c.QueryParam("order")),
+		Sort:        domain.ArticleSort(c.QueryParam("sort")),
+		Tags:        c.QueryParams()["tag"],
+		TitlePrefix: c.QueryParam("title_prefix"),
+	}
+	if q.Order != "" && !q.Order.IsValid() {
+		return domain.ArticleQuery{}, domain.ErrBadParamInput
+	}
+	if q.Sort != "" && !q.Sort.IsValid() {
+		return domain.ArticleQuery{}, fmt.Errorf("%w: unknown sort %q", domain.ErrBadParamInput, q.Sort)
+	}
+
+	for param, id := range map[string]*int64{"author_id": &q.AuthorID, "category_id": &q.CategoryID} {
+		value := c.QueryParam(param)
+		if value == "" {
+			continue
+		}
+		*id, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return domain.ArticleQuery{}, fmt.Errorf("%w: %s must be an id", domain.ErrBadParamInput, param)
+		}
+	}
+
+	for _, bound := range []struct {
+		param string
+		end   bool
+		at    **time.Time
+	}{
+		{"created_from", false, &q.CreatedFrom},
+		{"created_to", true, &q.CreatedTo},
+		{"updated_from", false, &q.UpdatedFrom},
+		{"updated_to", true, &q.UpdatedTo},
+	} {
+		*bound.at, err = dateBound(c.QueryParam(bound.param), bound.end)
+		if err != nil {
+			return domain.ArticleQuery{}, fmt.Errorf("%w: %s must be an RFC3339 time or a date", domain.ErrBadParamInput, bound.param)
+		}
+	}
+	return q, nil
+}
+
+// dateBound parses a bound of a date range, nil when it's left open
+func dateBound(value string, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return &at, nil
+	}
+
+	at, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		at = at.AddDate(0, 0, 1)
+	}
+	return &at, nil
+}
+
+// setPageHeaders exposes the next cursor through X-Cursor and both cursors through the Link header
+func setPageHeaders(c echo.Context, page domain.Page) {
+	c.Response().Header().Set(`X-Cursor`, page.Next)
+
+	links := make([]string, 0, 2)
+	if page.Next != "" {
+		links = append(links, pageLink(c, page.Next, "next"))
+	}
+	if page.Prev != "" {
+		links = append(links, pageLink(c, page.Prev, "prev"))
+	}
+	if len(links) > 0 {
+		c.Response().Header().Set(`Link`, strings.Join(links, ", "))
+	}
+}
+
+// pageLink builds the link to the current listing moved to the given cursor
+func pageLink(c echo.Context, cursor, rel string) string {
+	u := *c.Request().URL
+	query := u.Query()
+	query.Set("cursor", cursor)
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
+
+// GetByID will get the published article by given id, the others aren't found
 func (a *ArticleHandler) GetByID(c echo.Context) error {
+	return a.getByID(c, true)
+}
+
+// GetAnyByID will get the article by given id whatever its status
+func (a *ArticleHandler) GetAnyByID(c echo.Context) error {
+	return a.getByID(c, false)
+}
+
+// getByID serves the article of the id param, only the published ones are public. The views
+// of the public ones are recorded
+func (a *ArticleHandler) getByID(c echo.Context, public bool) error {
 	idP, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
@@ -79,10 +334,53 @@ func (a *ArticleHandler) GetByID(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	art, err := a.Service.GetByID(ctx, id)
+	if err == nil && public && art.Status != domain.StatusPublished {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	if public {
+		a.Service.RecordView(ctx, art, viewer(c))
+	}
+
+	c.Response().Header().Set(`ETag`, articleETag(art))
+	return c.JSON(http.StatusOK, art)
+}
+
+// GetBySlug will get the published article by given slug, a former slug of the article is
+// permanently redirected to its current one
+func (a *ArticleHandler) GetBySlug(c echo.Context) error {
+	return a.getBySlug(c, "/articles/by-slug/", true)
+}
+
+// GetAnyBySlug will get the article by given slug whatever its status
+func (a *ArticleHandler) GetAnyBySlug(c echo.Context) error {
+	return a.getBySlug(c, "/admin/articles/by-slug/", false)
+}
+
+// getBySlug serves the article of the slug param like getByID, the former slugs are redirected
+// to the current one under the prefix
+func (a *ArticleHandler) getBySlug(c echo.Context, prefix string, public bool) error {
+	slug := c.Param("slug")
+	ctx := c.Request().Context()
+
+	art, err := a.Service.GetBySlug(ctx, slug)
+	if err == nil && public && art.Status != domain.StatusPublished {
+		err = domain.ErrNotFound
+	}
 	if err != nil {
 		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
 
+	if art.Slug != slug {
+		return c.Redirect(http.StatusMovedPermanently, prefix+url.PathEscape(art.Slug))
+	}
+	if public {
+		a.Service.RecordView(ctx, art, viewer(c))
+	}
+
+	c.Response().Header().Set(`ETag`, articleETag(art))
 	return c.JSON(http.StatusOK, art)
 }
 
@@ -117,6 +415,93 @@ func (a *ArticleHandler) Store(c echo.Context) (err error) {
 	return c.JSON(http.StatusCreated, article)
 }
 
+// Update will replace the article by given request body, the If-Match header must carry its current ETag
+func (a *ArticleHandler) Update(c echo.Context) (err error) {
+	var article domain.Article
+	err = c.Bind(&article)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	return a.update(c, func(domain.Article) (domain.Article, error) {
+		return article, nil
+	})
+}
+
+// Patch will apply the JSON Merge Patch (RFC 7396) in the request body to the article,
+// the If-Match header must carry its current ETag
+func (a *ArticleHandler) Patch(c echo.Context) error {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, mimeApplicationMergePatchJSON) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return c.JSON(http.StatusUnsupportedMediaType, ResponseError{Message: "patch must be sent as " + mimeApplicationMergePatchJSON})
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	return a.update(c, func(current domain.Article) (domain.Article, error) {
+		return mergeArticle(current, patch)
+	})
+}
+
+// update runs the optimistic concurrency flow shared by Update and Patch,
+// build turns the currently stored article into the one to be saved
+func (a *ArticleHandler) update(c echo.Context, build func(current domain.Article) (domain.Article, error)) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	match := c.Request().Header.Get(`If-Match`)
+	if match == "" {
+		return c.JSON(http.StatusPreconditionRequired, ResponseError{Message: "If-Match header is required"})
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	current, err := a.Service.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	if !etagMatches(match, articleETag(current)) {
+		return c.JSON(http.StatusPreconditionFailed, ResponseError{Message: domain.ErrPreconditionFailed.Error()})
+	}
+
+	article, err := build(current)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var ok bool
+	if ok, err = isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	article.ID = current.ID
+	article.CreatedAt = current.CreatedAt
+	article.Version = current.Version
+	// the status only moves through Transition
+	article.Status = current.Status
+	article.PublishAt = current.PublishAt
+	err = a.Service.Update(ctx, &article)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	if article.Author.ID == current.Author.ID {
+		article.Author = current.Author
+	}
+	article.Categories = current.Categories
+	article.Tags = current.Tags
+
+	c.Response().Header().Set(`ETag`, articleETag(article))
+	return c.JSON(http.StatusOK, article)
+}
+
 // Delete will delete article by given param
 func (a *ArticleHandler) Delete(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
@@ -135,19 +520,159 @@ func (a *ArticleHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// Restore will take the article out of the trash
+func (a *ArticleHandler) Restore(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	art, err := a.Service.Restore(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	c.Response().Header().Set(`ETag`, articleETag(art))
+	return c.JSON(http.StatusOK, art)
+}
+
+// Transition will move the article to the status in the request body
+func (a *ArticleHandler) Transition(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req StatusRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	art, err := a.Service.Transition(ctx, id, req.Status, req.PublishAt)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	c.Response().Header().Set(`ETag`, articleETag(art))
+	return c.JSON(http.StatusOK, art)
+}
+
+// FetchTrash will fetch the trashed articles, the latest deleted first
+func (a *ArticleHandler) FetchTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	listAr, err := a.Service.FetchTrash(ctx)
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, listAr)
+}
+
+// setWarning reports a partially served response through the Warning header
+func setWarning(c echo.Context, err error) {
+	logrus.Warn(err)
+	c.Response().Header().Add(`Warning`, `199 - `+strconv.Quote(err.Error()))
+}
+
+// articleETag derives the article's entity tag from its version
+func articleETag(ar domain.Article) string {
+	return fmt.Sprintf(`"%d-%d"`, ar.ID, ar.Version)
+}
+
+// etagMatches reports whether the If-Match header value matches the given entity tag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeArticle applies the JSON Merge Patch to the article
+func mergeArticle(current domain.Article, patch []byte) (res domain.Article, err error) {
+	var patchDoc interface{}
+	err = json.Unmarshal(patch, &patchDoc)
+	if err != nil {
+		return
+	}
+	if _, ok := patchDoc.(map[string]interface{}); !ok {
+		return res, fmt.Errorf("merge patch must be a JSON object")
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return
+	}
+	var currentDoc interface{}
+	err = json.Unmarshal(currentJSON, &currentDoc)
+	if err != nil {
+		return
+	}
+
+	merged, err := json.Marshal(mergePatch(currentDoc, patchDoc))
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(merged, &res)
+	return
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
+
 func getStatusCode(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
 
 	logrus.Error(err)
-	switch err {
-	case domain.ErrInternalServerError:
+	switch {
+	case errors.Is(err, domain.ErrInternalServerError):
 		return http.StatusInternalServerError
-	case domain.ErrNotFound:
+	case errors.Is(err, domain.ErrNotFound):
 		return http.StatusNotFound
-	case domain.ErrConflict:
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrInvalidTransition):
 		return http.StatusConflict
+	case errors.Is(err, domain.ErrBadParamInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, domain.ErrEditWindowClosed), errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
 	}
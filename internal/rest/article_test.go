@@ -3,6 +3,7 @@ package rest_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -21,6 +22,8 @@ import (
 	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
 )
 
+const defaultNum = 10
+
 func TestFetch(t *testing.T) {
 	var mockArticle domain.Article
 	err := faker.FakeData(&mockArticle)
@@ -30,7 +33,8 @@ func TestFetch(t *testing.T) {
 	mockListArticle = append(mockListArticle, mockArticle)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(mockListArticle, "10", nil)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Cursor: cursor, Num: int64(num), Status: domain.StatusPublished}).
+		Return(mockListArticle, domain.Page{Next: "10", Prev: "1"}, nil)
 
 	e := echo.New()
 	req, err := http.NewRequestWithContext(context.TODO(),
@@ -47,15 +51,132 @@ func TestFetch(t *testing.T) {
 
 	responseCursor := rec.Header().Get("X-Cursor")
 	assert.Equal(t, "10", responseCursor)
+	assert.Equal(t, `</article?cursor=10&num=1>; rel="next", </article?cursor=1&num=1>; rel="prev"`, rec.Header().Get("Link"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchSummary(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	mockListArticle := []domain.Article{{ID: 1, Title: "Makan Ayam", Content: "<p>ayam goreng</p>", WordCount: 2, ReadingMinutes: 1, Excerpt: "ayam goreng"}}
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Num: 10, Status: domain.StatusPublished}).
+		Return(mockListArticle, domain.Page{}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?view=summary", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), `"content"`)
+	assert.Contains(t, rec.Body.String(), `"word_count":2,"reading_minutes":1,"excerpt":"ayam goreng"`)
 	mockUCase.AssertExpectations(t)
 }
 
+func TestFetchBadView(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?view=teaser", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything)
+}
+
+func TestFetchByTags(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Num: 10, Status: domain.StatusPublished, Tags: []string{"go", "mysql"}}).
+		Return([]domain.Article{}, domain.Page{}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?tag=go&tag=mysql", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchFilteredAndSorted(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	createdFrom := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, time.November, 18, 0, 0, 0, 0, time.UTC)
+	updatedFrom := time.Date(2024, time.November, 2, 10, 30, 0, 0, time.UTC)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{
+		Num: 10, Order: domain.SortDesc, Sort: domain.SortByTitle, Status: domain.StatusPublished,
+		AuthorID: 2, CategoryID: 3, TitlePrefix: "Go",
+		CreatedFrom: &createdFrom, CreatedTo: &createdTo, UpdatedFrom: &updatedFrom,
+	}).Return([]domain.Article{}, domain.Page{}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?sort=title&order=desc&author_id=2&category_id=3"+
+		"&title_prefix=Go&created_from=2024-11-01&created_to=2024-11-17&updated_from=2024-11-02T10:30:00Z", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchBadFilters(t *testing.T) {
+	for _, query := range []string{"sort=author", "author_id=iman", "updated_to=yesterday"} {
+		t.Run(query, func(t *testing.T) {
+			mockUCase := new(mocks.ArticleService)
+
+			e := echo.New()
+			req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?"+query, strings.NewReader(""))
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := rest.ArticleHandler{
+				Service: mockUCase,
+			}
+			err = handler.FetchArticle(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUCase.AssertExpectations(t)
+		})
+	}
+}
+
 func TestFetchError(t *testing.T) {
 	mockUCase := new(mocks.ArticleService)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(nil, "", domain.ErrInternalServerError)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Cursor: cursor, Num: int64(num), Status: domain.StatusPublished}).
+		Return(nil, domain.Page{}, domain.ErrInternalServerError)
 
 	e := echo.New()
 	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?num=1&cursor="+cursor, strings.NewReader(""))
@@ -75,20 +196,66 @@ func TestFetchError(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
+func TestFetchPartialResult(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	partialErr := fmt.Errorf("%w: author of 1 articles does not exist", domain.ErrPartialResult)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Num: defaultNum, Status: domain.StatusPublished}).
+		Return([]domain.Article{{ID: 1}}, domain.Page{Next: "10"}, partialErr)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+	assert.Contains(t, rec.Header().Get("Warning"), "199 - ")
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchInvalidOrder(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article?order=sideways", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
 func TestGetByID(t *testing.T) {
 	var mockArticle domain.Article
 	err := faker.FakeData(&mockArticle)
 	assert.NoError(t, err)
+	mockArticle.Status = domain.StatusPublished
 
 	mockUCase := new(mocks.ArticleService)
 
 	num := int(mockArticle.ID)
 
 	mockUCase.On("GetByID", mock.Anything, int64(num)).Return(mockArticle, nil)
+	mockUCase.On("RecordView", mock.Anything, mockArticle, "192.0.2.1 ").Once()
 
 	e := echo.New()
 	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/article/"+strconv.Itoa(num), strings.NewReader(""))
 	assert.NoError(t, err)
+	req.RemoteAddr = "192.0.2.1:4242"
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -105,6 +272,86 @@ func TestGetByID(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
+func TestGetBySlug(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	mockArticle := domain.Article{ID: 12, Title: "Makan Ayam", Slug: "makan-ayam", Status: domain.StatusPublished}
+	mockUCase.On("GetBySlug", mock.Anything, "makan-ayam").Return(mockArticle, nil).Once()
+	mockUCase.On("GetBySlug", mock.Anything, "ayam").Return(mockArticle, nil).Once()
+	// the redirect isn't a view
+	mockUCase.On("RecordView", mock.Anything, mockArticle, mock.AnythingOfType("string")).Once()
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+
+	tests := map[string]struct {
+		slug     string
+		code     int
+		location string
+	}{
+		"current": {"makan-ayam", http.StatusOK, ""},
+		"former":  {"ayam", http.StatusMovedPermanently, "/articles/by-slug/makan-ayam"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/by-slug/"+tc.slug, strings.NewReader(""))
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/articles/by-slug/:slug")
+			c.SetParamNames("slug")
+			c.SetParamValues(tc.slug)
+			err = handler.GetBySlug(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.code, rec.Code)
+			assert.Equal(t, tc.location, rec.Header().Get(echo.HeaderLocation))
+		})
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetDraft(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	draft := domain.Article{ID: 12, Title: "Makan Ayam", Slug: "makan-ayam", Status: domain.StatusDraft}
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(draft, nil)
+	mockUCase.On("GetBySlug", mock.Anything, "makan-ayam").Return(draft, nil)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+
+	tests := map[string]struct {
+		param  string
+		value  string
+		handle echo.HandlerFunc
+		code   int
+	}{
+		"by id":         {"id", "12", handler.GetByID, http.StatusNotFound},
+		"by slug":       {"slug", "makan-ayam", handler.GetBySlug, http.StatusNotFound},
+		"admin by id":   {"id", "12", handler.GetAnyByID, http.StatusOK},
+		"admin by slug": {"slug", "makan-ayam", handler.GetAnyBySlug, http.StatusOK},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/"+tc.value, strings.NewReader(""))
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(tc.param)
+			c.SetParamValues(tc.value)
+			err = tc.handle(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+	// the drafts aren't viewed
+	mockUCase.AssertNotCalled(t, "RecordView", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestStore(t *testing.T) {
 	mockArticle := domain.Article{
 		Title:     "Title",
@@ -170,3 +417,377 @@ func TestDelete(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 	mockUCase.AssertExpectations(t)
 }
+
+func TestUpdate(t *testing.T) {
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	mockArticle := domain.Article{
+		ID:        12,
+		Title:     "Title",
+		Content:   "Content",
+		Author:    domain.Author{ID: 1, Name: "Iman Tumorang"},
+		UpdatedAt: updatedAt,
+		CreatedAt: updatedAt,
+		Version:   3,
+	}
+	etag := `"12-3"`
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.ArticleService)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+		mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+			return ar.ID == 12 && ar.Title == "New Title" && ar.Version == 3
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Article).Version = 4
+		}).Return(nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12",
+			strings.NewReader(`{"title":"New Title","content":"New Content","author":{"id":1}}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", etag)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.ArticleHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"12-4"`, rec.Header().Get("ETag"))
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("missing-if-match", func(t *testing.T) {
+		mockUCase := new(mocks.ArticleService)
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12",
+			strings.NewReader(`{"title":"New Title","content":"New Content"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.ArticleHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("stale-etag", func(t *testing.T) {
+		mockUCase := new(mocks.ArticleService)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12",
+			strings.NewReader(`{"title":"New Title","content":"New Content"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		// read before the last edit, even if it was made within the same second
+		req.Header.Set("If-Match", `"12-2"`)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.ArticleHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("modified-concurrently", func(t *testing.T) {
+		mockUCase := new(mocks.ArticleService)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+		mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*domain.Article")).Return(domain.ErrPreconditionFailed).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12",
+			strings.NewReader(`{"title":"New Title","content":"New Content"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", etag)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.ArticleHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	mockArticle := domain.Article{
+		ID:        12,
+		Title:     "Title",
+		Content:   "Content",
+		Author:    domain.Author{ID: 1, Name: "Iman Tumorang"},
+		UpdatedAt: updatedAt,
+		CreatedAt: updatedAt,
+	}
+
+	mockUCase := new(mocks.ArticleService)
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.Title == "New Title" && ar.Content == "Content" && ar.Author.ID == 1
+	})).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.PATCH, "/articles/12", strings.NewReader(`{"title":"New Title"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+	req.Header.Set("If-Match", "*")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res domain.Article
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "New Title", res.Title)
+	assert.Equal(t, "Content", res.Content)
+	assert.Equal(t, "Iman Tumorang", res.Author.Name)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSearch(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	hits := []domain.SearchHit{{Article: domain.Article{ID: 2, Title: "Makan Ikan"}, Score: 0.9, Snippet: "<mark>ikan</mark> bakar"}}
+	mockUCase.On("Search", mock.Anything, domain.SearchQuery{Terms: "ikan bakar", Num: 1}).
+		Return(hits, domain.Page{Next: "next-cursor"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/search?q=ikan+bakar&num=1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Search(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "next-cursor", rec.Header().Get("X-Cursor"))
+
+	var res []domain.SearchHit
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, hits, res)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSearchWithoutTerms(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/search?q=+", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Search(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSemanticSearch(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	hits := []domain.SearchHit{{Article: domain.Article{ID: 3, Title: "Makan Ayam"}, Score: 0.8}}
+	mockUCase.On("SemanticSearch", mock.Anything, "ayam goreng", int64(defaultNum)).Return(hits, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/semantic?q=ayam+goreng", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.SemanticSearch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "snippet")
+
+	var res []domain.SearchHit
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, hits, res)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestTrending(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	trending := []domain.TrendingArticle{{Article: domain.Article{ID: 3, Title: "Makan Ayam"}, Views: 40, Score: 31.5}}
+	mockUCase.On("Trending", mock.Anything, 6*time.Hour, int64(5)).Return(trending, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/trending?window=6h&num=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Trending(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res []domain.TrendingArticle
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, trending, res)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestTrendingBadWindow(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/trending?window=yesterday", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Trending(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Trending", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestore(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	mockUCase.On("Restore", mock.Anything, int64(12)).Return(domain.Article{ID: 12, UpdatedAt: updatedAt, Version: 5}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/12/restore", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/restore")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+	err = handler.Restore(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"12-5"`, rec.Header().Get("ETag"))
+	mockUCase.AssertExpectations(t)
+}
+
+func TestTransition(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	publishAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	mockUCase.On("Transition", mock.Anything, int64(12), domain.StatusScheduled, &publishAt).
+		Return(domain.Article{ID: 12, Status: domain.StatusScheduled, PublishAt: &publishAt, UpdatedAt: publishAt}, nil).Once()
+	mockUCase.On("Transition", mock.Anything, int64(12), domain.StatusPublished, (*time.Time)(nil)).
+		Return(domain.Article{}, domain.ErrInvalidTransition).Once()
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+
+	tests := map[string]struct {
+		body string
+		code int
+	}{
+		"schedule":       {`{"status":"scheduled","publish_at":"2024-11-17T15:16:15Z"}`, http.StatusOK},
+		"not-allowed":    {`{"status":"published"}`, http.StatusConflict},
+		"missing-status": {`{}`, http.StatusBadRequest},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/12/status", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/articles/:id/status")
+			c.SetParamNames("id")
+			c.SetParamValues("12")
+			err = handler.Transition(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchAll(t *testing.T) {
+	mockUCase := new(mocks.ArticleService)
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{Num: defaultNum, Status: domain.StatusDraft}).
+		Return([]domain.Article{{ID: 12, Status: domain.StatusDraft}}, domain.Page{}, nil).Once()
+	handler := rest.ArticleHandler{
+		Service: mockUCase,
+	}
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/admin/articles?status=draft", strings.NewReader(""))
+	assert.NoError(t, err)
+	rec := httptest.NewRecorder()
+	err = handler.FetchAll(e.NewContext(req, rec))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req, err = http.NewRequestWithContext(context.TODO(), echo.GET, "/admin/articles?status=lost", strings.NewReader(""))
+	assert.NoError(t, err)
+	rec = httptest.NewRecorder()
+	err = handler.FetchAll(e.NewContext(req, rec))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockUCase.AssertExpectations(t)
+}
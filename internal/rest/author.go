@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// AuthorService represent the author's usecases
+//
+//go:generate mockery --name AuthorService
+type AuthorService interface {
+	Fetch(ctx context.Context) ([]domain.Author, error)
+	GetByID(ctx context.Context, id int64) (domain.Author, error)
+	Store(ctx context.Context, a *domain.Author) error
+	Update(ctx context.Context, a *domain.Author) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// AuthorHandler  represent the httphandler for author
+type AuthorHandler struct {
+	Service AuthorService
+}
+
+// NewAuthorHandler will initialize the authors/ resources endpoint
+func NewAuthorHandler(e *echo.Echo, svc AuthorService) {
+	handler := &AuthorHandler{
+		Service: svc,
+	}
+	e.GET("/authors", handler.FetchAuthor)
+	e.POST("/authors", handler.Store)
+	e.GET("/authors/:id", handler.GetByID)
+	e.PUT("/authors/:id", handler.Update)
+	e.DELETE("/authors/:id", handler.Delete)
+}
+
+// FetchAuthor will fetch every author
+func (h *AuthorHandler) FetchAuthor(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := h.Service.Fetch(ctx)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetByID will get author by given id
+func (h *AuthorHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	author, err := h.Service.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, author)
+}
+
+func isAuthorRequestValid(a *domain.Author) bool {
+	a.Name = strings.TrimSpace(a.Name)
+	return a.Name != ""
+}
+
+// Store will store the author by given request body
+func (h *AuthorHandler) Store(c echo.Context) (err error) {
+	var author domain.Author
+	err = c.Bind(&author)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if !isAuthorRequestValid(&author) {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "name is required"})
+	}
+
+	ctx := c.Request().Context()
+	err = h.Service.Store(ctx, &author)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, author)
+}
+
+// Update will rename the author by given request body
+func (h *AuthorHandler) Update(c echo.Context) (err error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var author domain.Author
+	err = c.Bind(&author)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if !isAuthorRequestValid(&author) {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "name is required"})
+	}
+
+	author.ID = id
+	ctx := c.Request().Context()
+	err = h.Service.Update(ctx, &author)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, author)
+}
+
+// Delete will delete author by given param
+func (h *AuthorHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = h.Service.Delete(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
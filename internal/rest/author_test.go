@@ -0,0 +1,88 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestStoreAuthor(t *testing.T) {
+	mockUCase := new(mocks.AuthorService)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(a *domain.Author) bool {
+		return a.Name == "Iman Tumorang"
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/authors", strings.NewReader(`{"name":" Iman Tumorang "}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/authors")
+
+	handler := rest.AuthorHandler{
+		Service: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreAuthorWithoutName(t *testing.T) {
+	mockUCase := new(mocks.AuthorService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/authors", strings.NewReader(`{"name":"  "}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/authors")
+
+	handler := rest.AuthorHandler{
+		Service: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteAuthorWithArticles(t *testing.T) {
+	mockUCase := new(mocks.AuthorService)
+	mockUCase.On("Delete", mock.Anything, int64(1)).Return(domain.ErrConflict)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.DELETE, "/authors/1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/authors/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := rest.AuthorHandler{
+		Service: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
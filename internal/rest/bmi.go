@@ -6,18 +6,22 @@ import (
 	"github.com/bxcodec/go-clean-arch/domain"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"golang.org/x/text/language"
 	"net/http"
+	"slices"
 	"strconv"
 )
 
 type BmiService interface {
-	CalculateAndStoreBMI(ctx context.Context, height, weight float64) (*domain.BMI, error)
-	GetBMIByID(ctx context.Context, id int64) (*domain.BMI, error)
-	GetAllBMI(ctx context.Context) ([]*domain.BMI, error)
+	CalculateAndStoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error)
+	GetBMIByID(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error)
+	GetAllBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error)
 	UpdateBMI(ctx context.Context, bmi *domain.BMI) error
 	DeleteBMI(ctx context.Context, id int64) error
+	RestoreBMI(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error)
+	GetTrashedBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error)
 	QueryBMI(ctx context.Context, queryVector []float32) ([]*domain.BMI, error)
-	StoreBMI(ctx context.Context, height, weight float64) (*domain.BMI, error)
+	StoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error)
 }
 
 type BmiHandler struct {
@@ -35,6 +39,8 @@ func NewBmiHandler(e *echo.Echo, b BmiService) {
 	e.GET("/bmi", handler.GetAllBMI)
 	e.PUT("/bmi/:id", handler.UpdateBMI)
 	e.DELETE("/bmi/:id", handler.DeleteBMI)
+	e.POST("/bmi/:id/restore", handler.RestoreBMI)
+	e.GET("/admin/bmi/trash", handler.GetTrashedBMI)
 	e.POST("/bmi/query", handler.QueryBMI)
 }
 
@@ -49,9 +55,9 @@ func (h *BmiHandler) CalculateAndStoreBMI(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	bmi, err := h.BmiSrv.CalculateAndStoreBMI(ctx, req.Height, req.Weight)
+	bmi, err := h.BmiSrv.CalculateAndStoreBMI(ctx, req, bmiOptions(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, bmi)
@@ -65,12 +71,12 @@ func (h *BmiHandler) GetBMIByID(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	bmi, err := h.BmiSrv.GetBMIByID(ctx, id)
+	bmi, err := h.BmiSrv.GetBMIByID(ctx, id, bmiOptions(c))
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "BMI record not found"})
 		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, bmi)
@@ -78,9 +84,9 @@ func (h *BmiHandler) GetBMIByID(c echo.Context) error {
 
 func (h *BmiHandler) GetAllBMI(c echo.Context) error {
 	ctx := c.Request().Context()
-	bmis, err := h.BmiSrv.GetAllBMI(ctx)
+	bmis, err := h.BmiSrv.GetAllBMI(ctx, bmiOptions(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, bmis)
@@ -102,7 +108,7 @@ func (h *BmiHandler) UpdateBMI(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.BmiSrv.UpdateBMI(ctx, &req); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "BMI record updated successfully"})
@@ -120,19 +126,48 @@ func (h *BmiHandler) DeleteBMI(c echo.Context) error {
 		if errors.Is(err, domain.ErrNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "BMI record not found"})
 		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "BMI record deleted successfully"})
 }
 
+func (h *BmiHandler) RestoreBMI(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	bmi, err := h.BmiSrv.RestoreBMI(ctx, id, bmiOptions(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "BMI record not found in trash"})
+		}
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, bmi)
+}
+
+func (h *BmiHandler) GetTrashedBMI(c echo.Context) error {
+	ctx := c.Request().Context()
+	bmis, err := h.BmiSrv.GetTrashedBMI(ctx, bmiOptions(c))
+	if err != nil {
+		return c.JSON(bmiStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, bmis)
+}
+
 func (h *BmiHandler) StoreBMI(c echo.Context) error {
 	var req domain.BMICalculationRequest
 	if err := c.Bind(&req); err != nil {
 	}
 
 	ctx := c.Request().Context()
-	bmi, err := h.BmiSrv.StoreBMI(ctx, req.Height, req.Weight)
+	bmi, err := h.BmiSrv.StoreBMI(ctx, req, bmiOptions(c))
 	if err != nil {
 		return nil
 	}
@@ -157,3 +192,38 @@ func (h *BmiHandler) QueryBMI(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, results)
 }
+
+// bmiOptions reads how the client wants the records presented, ?scheme= picks the classification
+// scheme, ?units= the unit system and Accept-Language the language of the labels
+func bmiOptions(c echo.Context) domain.BMIOptions {
+	return domain.BMIOptions{
+		Scheme:    c.QueryParam("scheme"),
+		Languages: acceptLanguages(c.Request().Header.Get("Accept-Language")),
+		Units:     c.QueryParam("units"),
+	}
+}
+
+// acceptLanguages lists the base languages of the header, the preferred first. A malformed header
+// accepts no language in particular
+func acceptLanguages(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	var languages []string
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		if !slices.Contains(languages, base.String()) {
+			languages = append(languages, base.String())
+		}
+	}
+	return languages
+}
+
+// bmiStatusCode maps the service errors like getStatusCode does, an invalid measurement is always a 400
+func bmiStatusCode(err error) int {
+	if errors.Is(err, domain.ErrBadParamInput) {
+		return http.StatusBadRequest
+	}
+	return getStatusCode(err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/bxcodec/go-clean-arch/domain"
 	"github.com/bxcodec/go-clean-arch/internal/rest"
 	"github.com/labstack/echo/v4"
@@ -21,24 +22,24 @@ type MockBMIService struct {
 	mock.Mock
 }
 
-func (m *MockBMIService) CalculateAndStoreBMI(ctx context.Context, height, weight float64) (*domain.BMI, error) {
-	args := m.Called(ctx, height, weight)
+func (m *MockBMIService) CalculateAndStoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error) {
+	args := m.Called(ctx, req, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.BMI), args.Error(1)
 }
 
-func (m *MockBMIService) GetBMIByID(ctx context.Context, id int64) (*domain.BMI, error) {
-	args := m.Called(ctx, id)
+func (m *MockBMIService) GetBMIByID(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error) {
+	args := m.Called(ctx, id, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.BMI), args.Error(1)
 }
 
-func (m *MockBMIService) GetAllBMI(ctx context.Context) ([]*domain.BMI, error) {
-	args := m.Called(ctx)
+func (m *MockBMIService) GetAllBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error) {
+	args := m.Called(ctx, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -55,6 +56,38 @@ func (m *MockBMIService) DeleteBMI(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+func (m *MockBMIService) RestoreBMI(ctx context.Context, id int64, opts domain.BMIOptions) (*domain.BMI, error) {
+	args := m.Called(ctx, id, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BMI), args.Error(1)
+}
+
+func (m *MockBMIService) GetTrashedBMI(ctx context.Context, opts domain.BMIOptions) ([]*domain.BMI, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BMI), args.Error(1)
+}
+
+func (m *MockBMIService) QueryBMI(ctx context.Context, queryVector []float32) ([]*domain.BMI, error) {
+	args := m.Called(ctx, queryVector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BMI), args.Error(1)
+}
+
+func (m *MockBMIService) StoreBMI(ctx context.Context, req domain.BMICalculationRequest, opts domain.BMIOptions) (*domain.BMI, error) {
+	args := m.Called(ctx, req, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BMI), args.Error(1)
+}
+
 func TestCalculateAndStoreBMIHandler(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockBMIService)
@@ -78,7 +111,7 @@ func TestCalculateAndStoreBMIHandler(t *testing.T) {
 			Value:     22.857142857142858,
 			CreatedAt: timestamp,
 		}
-		mockService.On("CalculateAndStoreBMI", mock.Anything, 1.75, 70.0).Return(expectedBMI, nil)
+		mockService.On("CalculateAndStoreBMI", mock.Anything, domain.BMICalculationRequest{Height: 1.75, Weight: 70.0}, domain.BMIOptions{}).Return(expectedBMI, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bmi", bytes.NewReader(jsonBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -134,7 +167,7 @@ func TestCalculateAndStoreBMIHandler_ServiceError(t *testing.T) {
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		mockService.On("CalculateAndStoreBMI", mock.Anything, 1.75, 70.0).Return(nil, errors.New("internal error"))
+		mockService.On("CalculateAndStoreBMI", mock.Anything, domain.BMICalculationRequest{Height: 1.75, Weight: 70.0}, domain.BMIOptions{}).Return(nil, errors.New("internal error"))
 
 		req := httptest.NewRequest(http.MethodPost, "/bmi", bytes.NewReader(jsonBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -168,7 +201,7 @@ func TestGetBMIByIDHandler(t *testing.T) {
 			Value:     22.857142857142858,
 			CreatedAt: timestamp,
 		}
-		mockService.On("GetBMIByID", mock.Anything, int64(1)).Return(expectedBMI, nil)
+		mockService.On("GetBMIByID", mock.Anything, int64(1), domain.BMIOptions{}).Return(expectedBMI, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bmi/1", nil)
 		rec := httptest.NewRecorder()
@@ -212,7 +245,7 @@ func TestGetAllBMIHandler(t *testing.T) {
 			{ID: 1, Height: 1.75, Weight: 70.0, Value: 22.857142857142858, CreatedAt: timestamp},
 			{ID: 2, Height: 1.80, Weight: 75.0, Value: 23.148148148148145, CreatedAt: timestamp},
 		}
-		mockService.On("GetAllBMI", mock.Anything).Return(expectedBMIs, nil)
+		mockService.On("GetAllBMI", mock.Anything, domain.BMIOptions{}).Return(expectedBMIs, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bmi", nil)
 		rec := httptest.NewRecorder()
@@ -273,3 +306,185 @@ func TestDeleteBMIHandler(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestUpdateBMIHandler(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockBMIService)
+	handler := &rest.BmiHandler{BmiSrv: mockService}
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid measurement", domain.ErrBadParamInput, http.StatusBadRequest},
+		{"record not found", domain.ErrNotFound, http.StatusNotFound},
+		{"conflict", domain.ErrConflict, http.StatusConflict},
+		{"service error", errors.New("internal error"), http.StatusInternalServerError},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := int64(i + 1)
+			mockService.On("UpdateBMI", mock.Anything, mock.MatchedBy(func(bmi *domain.BMI) bool {
+				return bmi.ID == id
+			})).Return(tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodPut, "/bmi/"+strconv.FormatInt(id, 10), bytes.NewReader([]byte(`{"height":1.8,"weight":80}`)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.FormatInt(id, 10))
+
+			err := handler.UpdateBMI(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestRestoreBMIHandler(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockBMIService)
+	handler := &rest.BmiHandler{BmiSrv: mockService}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("RestoreBMI", mock.Anything, int64(1), domain.BMIOptions{}).Return(&domain.BMI{ID: 1, Value: 22.86}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/bmi/1/restore", nil)
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.RestoreBMI(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not in trash", func(t *testing.T) {
+		mockService.On("RestoreBMI", mock.Anything, int64(999), domain.BMIOptions{}).Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/bmi/999/restore", nil)
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("999")
+
+		err := handler.RestoreBMI(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGetAllBMIHandlerScheme(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockBMIService)
+	handler := &rest.BmiHandler{BmiSrv: mockService}
+
+	t.Run("scheme", func(t *testing.T) {
+		expectedBMIs := []*domain.BMI{{ID: 1, Value: 24.22, Category: "Normal range", Risk: "Average", Scheme: "who"}}
+		mockService.On("GetAllBMI", mock.Anything, domain.BMIOptions{Scheme: "who"}).Return(expectedBMIs, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/bmi?scheme=who", nil)
+		rec := httptest.NewRecorder()
+
+		err := handler.GetAllBMI(e.NewContext(req, rec))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"scheme":"who"`)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		mockService.On("GetAllBMI", mock.Anything, domain.BMIOptions{Scheme: "unknown"}).
+			Return(nil, fmt.Errorf("%w: unknown classification scheme", domain.ErrBadParamInput)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/bmi?scheme=unknown", nil)
+		rec := httptest.NewRecorder()
+
+		err := handler.GetAllBMI(e.NewContext(req, rec))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGetBMIByIDHandlerAcceptLanguage(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockBMIService)
+	handler := &rest.BmiHandler{BmiSrv: mockService}
+
+	expectedBMI := &domain.BMI{ID: 1, Value: 24.22, Category: "Overweight", CategoryCode: "overweight", Language: "en"}
+	mockService.On("GetBMIByID", mock.Anything, int64(1), domain.BMIOptions{Languages: []string{"en", "th"}}).
+		Return(expectedBMI, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/bmi/1", nil)
+	req.Header.Set("Accept-Language", "th;q=0.5, en-US, en;q=0.8")
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := handler.GetBMIByID(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"category_code":"overweight"`)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCalculateAndStoreBMIHandlerUnits(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockBMIService)
+	handler := &rest.BmiHandler{BmiSrv: mockService}
+
+	t.Run("units", func(t *testing.T) {
+		request := domain.BMICalculationRequest{Height: 175, HeightUnit: "cm", Weight: 154, WeightUnit: "lb"}
+		expectedBMI := &domain.BMI{ID: 1, Height: 5, HeightInches: 8.9, HeightUnit: "ft", Weight: 154, WeightUnit: "lb"}
+		mockService.On("CalculateAndStoreBMI", mock.Anything, request, domain.BMIOptions{Units: "imperial"}).
+			Return(expectedBMI, nil).Once()
+
+		jsonBody, _ := json.Marshal(request)
+		req := httptest.NewRequest(http.MethodPost, "/bmi?units=imperial", bytes.NewReader(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		err := handler.CalculateAndStoreBMI(e.NewContext(req, rec))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"height_unit":"ft"`)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("implausible", func(t *testing.T) {
+		request := domain.BMICalculationRequest{Height: 175, Weight: 70}
+		mockService.On("CalculateAndStoreBMI", mock.Anything, request, domain.BMIOptions{}).
+			Return(nil, fmt.Errorf("%w: a height of 175.00 m is not plausible", domain.ErrBadParamInput)).Once()
+
+		jsonBody, _ := json.Marshal(request)
+		req := httptest.NewRequest(http.MethodPost, "/bmi", bytes.NewReader(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		err := handler.CalculateAndStoreBMI(e.NewContext(req, rec))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "not plausible")
+
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// CategoryService represent the category's usecases
+//
+//go:generate mockery --name CategoryService
+type CategoryService interface {
+	FetchCategories(ctx context.Context) ([]domain.Category, error)
+	FetchByCategory(ctx context.Context, categoryID int64, q domain.ArticleQuery) ([]domain.Article, domain.Page, error)
+	AddCategory(ctx context.Context, articleID, categoryID int64) error
+	RemoveCategory(ctx context.Context, articleID, categoryID int64) error
+}
+
+// CategoryHandler  represent the httphandler for category
+type CategoryHandler struct {
+	Service CategoryService
+}
+
+// NewCategoryHandler will initialize the categories/ resources endpoint
+func NewCategoryHandler(e *echo.Echo, svc CategoryService) {
+	handler := &CategoryHandler{
+		Service: svc,
+	}
+	e.GET("/categories", handler.FetchCategories)
+	e.GET("/categories/:id/articles", handler.FetchArticles)
+	e.PUT("/articles/:id/categories/:categoryID", handler.AddCategory)
+	e.DELETE("/articles/:id/categories/:categoryID", handler.RemoveCategory)
+}
+
+// FetchCategories will fetch every category
+func (h *CategoryHandler) FetchCategories(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := h.Service.FetchCategories(ctx)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// FetchArticles will fetch the published articles of the given category
+func (h *CategoryHandler) FetchArticles(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	q, err := articleQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	q.Status = domain.StatusPublished
+
+	ctx := c.Request().Context()
+
+	listAr, page, err := h.Service.FetchByCategory(ctx, int64(idP), q)
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	setPageHeaders(c, page)
+	return c.JSON(http.StatusOK, listAr)
+}
+
+// AddCategory will assign the category to the article
+func (h *CategoryHandler) AddCategory(c echo.Context) error {
+	articleID, categoryID, err := articleCategoryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = h.Service.AddCategory(ctx, articleID, categoryID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveCategory will unassign the category from the article
+func (h *CategoryHandler) RemoveCategory(c echo.Context) error {
+	articleID, categoryID, err := articleCategoryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = h.Service.RemoveCategory(ctx, articleID, categoryID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func articleCategoryParams(c echo.Context) (articleID, categoryID int64, err error) {
+	articleID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return
+	}
+	categoryID, err = strconv.ParseInt(c.Param("categoryID"), 10, 64)
+	return
+}
@@ -0,0 +1,110 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestFetchCategories(t *testing.T) {
+	mockUCase := new(mocks.CategoryService)
+	mockUCase.On("FetchCategories", mock.Anything).Return([]domain.Category{{ID: 1, Name: "Golang"}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/categories", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.CategoryHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchCategories(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchCategoryArticles(t *testing.T) {
+	mockUCase := new(mocks.CategoryService)
+	cursor := "2"
+	mockUCase.On("FetchByCategory", mock.Anything, int64(3), domain.ArticleQuery{Cursor: cursor, Num: 1, Order: domain.SortDesc, Status: domain.StatusPublished}).
+		Return([]domain.Article{{ID: 1}}, domain.Page{Next: "10"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/categories/3/articles?num=1&order=desc&cursor="+cursor, strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/categories/:id/articles")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := rest.CategoryHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchArticles(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAddCategory(t *testing.T) {
+	mockUCase := new(mocks.CategoryService)
+	mockUCase.On("AddCategory", mock.Anything, int64(12), int64(3)).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/categories/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/categories/:categoryID")
+	c.SetParamNames("id", "categoryID")
+	c.SetParamValues("12", "3")
+	handler := rest.CategoryHandler{
+		Service: mockUCase,
+	}
+	err = handler.AddCategory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRemoveCategoryNotFound(t *testing.T) {
+	mockUCase := new(mocks.CategoryService)
+	mockUCase.On("RemoveCategory", mock.Anything, int64(12), int64(3)).Return(domain.ErrNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.DELETE, "/articles/12/categories/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/categories/:categoryID")
+	c.SetParamNames("id", "categoryID")
+	c.SetParamValues("12", "3")
+	handler := rest.CategoryHandler{
+		Service: mockUCase,
+	}
+	err = handler.RemoveCategory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,221 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// CommentService represent the comment's usecases
+//
+//go:generate mockery --name CommentService
+type CommentService interface {
+	Fetch(ctx context.Context, q domain.CommentQuery) ([]domain.Comment, domain.Page, error)
+	Store(ctx context.Context, c *domain.Comment) error
+	Update(ctx context.Context, c *domain.Comment) error
+	Moderate(ctx context.Context, id int64, status domain.CommentStatus) (domain.Comment, error)
+}
+
+// CommentHandler  represent the httphandler for article comments
+type CommentHandler struct {
+	Service CommentService
+}
+
+// NewCommentHandler will initialize the articles/:id/comments resources endpoint
+func NewCommentHandler(e *echo.Echo, svc CommentService) {
+	handler := &CommentHandler{
+		Service: svc,
+	}
+	e.GET("/articles/:id/comments", handler.FetchComments)
+	e.POST("/articles/:id/comments", handler.Store)
+	e.GET("/articles/:id/comments/:commentID/replies", handler.FetchReplies)
+	e.PUT("/articles/:id/comments/:commentID", handler.Update)
+	e.GET("/admin/comments", handler.FetchAll)
+	e.POST("/admin/comments/:commentID/status", handler.Moderate)
+}
+
+// EditCommentRequest represent the request body editing a comment
+type EditCommentRequest struct {
+	Content string `json:"content" validate:"required"`
+	// EditToken is the one handed out along with the comment when it was posted
+	EditToken string `json:"edit_token" validate:"required"`
+}
+
+// ModerationRequest represent the request body moderating a comment
+type ModerationRequest struct {
+	Status domain.CommentStatus `json:"status" validate:"required"`
+}
+
+// FetchComments will fetch the approved top-level comments of the article
+func (h *CommentHandler) FetchComments(c echo.Context) error {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	q, err := commentQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	topLevel := int64(0)
+	q.ArticleID = articleID
+	q.ParentID = &topLevel
+	q.Status = domain.CommentApproved
+
+	return h.fetch(c, q)
+}
+
+// FetchReplies will fetch the approved replies to the comment
+func (h *CommentHandler) FetchReplies(c echo.Context) error {
+	articleID, commentID, err := articleCommentParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	q, err := commentQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	q.ArticleID = articleID
+	q.ParentID = &commentID
+	q.Status = domain.CommentApproved
+
+	return h.fetch(c, q)
+}
+
+// FetchAll will fetch the comments of every article at every depth, the status param narrows them down to one
+func (h *CommentHandler) FetchAll(c echo.Context) error {
+	q, err := commentQuery(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	q.Status = domain.CommentStatus(c.QueryParam("status"))
+	if q.Status != "" && !q.Status.IsValid() {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "unknown status " + strconv.Quote(string(q.Status))})
+	}
+
+	return h.fetch(c, q)
+}
+
+// fetch serves one page of the comment listing
+func (h *CommentHandler) fetch(c echo.Context, q domain.CommentQuery) error {
+	ctx := c.Request().Context()
+
+	list, page, err := h.Service.Fetch(ctx, q)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	setPageHeaders(c, page)
+	return c.JSON(http.StatusOK, list)
+}
+
+// Store will post the comment in the request body on the article, it's held for moderation.
+// The response carries the edit token, the only time it's shown
+func (h *CommentHandler) Store(c echo.Context) (err error) {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var comment domain.Comment
+	err = c.Bind(&comment)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(comment)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	comment.ArticleID = articleID
+	ctx := c.Request().Context()
+	err = h.Service.Store(ctx, &comment)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, comment)
+}
+
+// Update will replace the content of the comment by given request body, while its edit window is open.
+// The body must carry the edit token the comment was posted with
+func (h *CommentHandler) Update(c echo.Context) (err error) {
+	articleID, commentID, err := articleCommentParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req EditCommentRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	comment := domain.Comment{ID: commentID, ArticleID: articleID, Content: req.Content, EditToken: req.EditToken}
+	ctx := c.Request().Context()
+	err = h.Service.Update(ctx, &comment)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// Moderate will move the comment to the moderation status in the request body
+func (h *CommentHandler) Moderate(c echo.Context) error {
+	commentID, err := strconv.ParseInt(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req ModerationRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	comment, err := h.Service.Moderate(ctx, commentID, req.Status)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// commentQuery reads the num, cursor and order paging params
+func commentQuery(c echo.Context) (domain.CommentQuery, error) {
+	order := domain.SortOrder(c.QueryParam("order"))
+	if order != "" && !order.IsValid() {
+		return domain.CommentQuery{}, domain.ErrBadParamInput
+	}
+
+	return domain.CommentQuery{
+		Cursor: c.QueryParam("cursor"),
+		Num:    pageSize(c),
+		Order:  order,
+	}, nil
+}
+
+func articleCommentParams(c echo.Context) (articleID, commentID int64, err error) {
+	articleID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return
+	}
+	commentID, err = strconv.ParseInt(c.Param("commentID"), 10, 64)
+	return
+}
@@ -0,0 +1,272 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestFetchComments(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+	mockUCase.On("Fetch", mock.Anything, mock.MatchedBy(func(q domain.CommentQuery) bool {
+		return q.ArticleID == 12 && q.ParentID != nil && *q.ParentID == 0 &&
+			q.Status == domain.CommentApproved && q.Cursor == "abc" && q.Num == 2
+	})).Return([]domain.Comment{{ID: 1, ArticleID: 12}}, domain.Page{Next: "next"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/12/comments?num=2&cursor=abc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/comments")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.CommentHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchComments(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "next", rec.Header().Get("X-Cursor"))
+	assert.Contains(t, rec.Header().Get("Link"), `rel="next"`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchReplies(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+	mockUCase.On("Fetch", mock.Anything, mock.MatchedBy(func(q domain.CommentQuery) bool {
+		return q.ArticleID == 12 && q.ParentID != nil && *q.ParentID == 3 && q.Status == domain.CommentApproved
+	})).Return([]domain.Comment{}, domain.Page{}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/12/comments/3/replies", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/comments/:commentID/replies")
+	c.SetParamNames("id", "commentID")
+	c.SetParamValues("12", "3")
+	handler := rest.CommentHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchReplies(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchAllCommentsUnknownStatus(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/admin/comments?status=spam", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.CommentHandler{
+		Service: mockUCase,
+	}
+	err = handler.FetchAll(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreComment(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.ArticleID == 12 && c.ParentID == 3 && c.AuthorName == "Iman"
+		})).Run(func(args mock.Arguments) {
+			c := args.Get(1).(*domain.Comment)
+			c.ID = 7
+			c.Status = domain.CommentPending
+		}).Return(nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/12/comments",
+			strings.NewReader(`{"author_name":"Iman","content":"Nice","parent_id":3}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var got domain.Comment
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, int64(7), got.ID)
+		assert.Equal(t, domain.CommentPending, got.Status)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("without-author-name", func(t *testing.T) {
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/12/comments",
+			strings.NewReader(`{"content":"Nice"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestUpdateComment(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase.On("Update", mock.Anything, &domain.Comment{ID: 7, ArticleID: 12, Content: "Edited", EditToken: "secret"}).
+			Return(nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/comments/7",
+			strings.NewReader(`{"content":"Edited","edit_token":"secret"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments/:commentID")
+		c.SetParamNames("id", "commentID")
+		c.SetParamValues("12", "7")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("edit-window-closed", func(t *testing.T) {
+		mockUCase.On("Update", mock.Anything, mock.Anything).Return(domain.ErrEditWindowClosed).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/comments/8",
+			strings.NewReader(`{"content":"Edited","edit_token":"secret"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments/:commentID")
+		c.SetParamNames("id", "commentID")
+		c.SetParamValues("12", "8")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("wrong-edit-token", func(t *testing.T) {
+		mockUCase.On("Update", mock.Anything, mock.Anything).Return(domain.ErrForbidden).Once()
+
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/comments/9",
+			strings.NewReader(`{"content":"Edited","edit_token":"guess"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments/:commentID")
+		c.SetParamNames("id", "commentID")
+		c.SetParamValues("12", "9")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("missing-edit-token", func(t *testing.T) {
+		e := echo.New()
+		req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/comments/9",
+			strings.NewReader(`{"content":"Edited"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/articles/:id/comments/:commentID")
+		c.SetParamNames("id", "commentID")
+		c.SetParamValues("12", "9")
+		handler := rest.CommentHandler{
+			Service: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestModerateComment(t *testing.T) {
+	mockUCase := new(mocks.CommentService)
+	mockUCase.On("Moderate", mock.Anything, int64(7), domain.CommentApproved).
+		Return(domain.Comment{ID: 7, Status: domain.CommentApproved}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/admin/comments/7/status",
+		strings.NewReader(`{"status":"approved"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/admin/comments/:commentID/status")
+	c.SetParamNames("commentID")
+	c.SetParamValues("7")
+	handler := rest.CommentHandler{
+		Service: mockUCase,
+	}
+	err = handler.Moderate(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
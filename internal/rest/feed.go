@@ -0,0 +1,333 @@
+package rest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// FeedService represent the article listing the feeds are made of, and the authors and
+// categories the feeds can be narrowed down to
+//
+//go:generate mockery --name FeedService
+type FeedService interface {
+	Fetch(ctx context.Context, q domain.ArticleQuery) ([]domain.Article, domain.Page, error)
+	GetAuthor(ctx context.Context, id int64) (domain.Author, error)
+	GetCategory(ctx context.Context, id int64) (domain.Category, error)
+}
+
+// FeedHandler  represent the httphandler for the RSS and Atom feeds of the published articles
+type FeedHandler struct {
+	Service FeedService
+	// Items is how many articles a feed carries, the latest ones
+	Items int64
+}
+
+const (
+	// DefaultFeedItems is how many articles a feed carries when no count is configured
+	DefaultFeedItems = 20
+
+	mimeApplicationRSS  = "application/rss+xml"
+	mimeApplicationAtom = "application/atom+xml"
+)
+
+// NewFeedHandler will initialize the feeds/ resources endpoint, each feed carries the given
+// number of articles, DefaultFeedItems when it isn't positive
+func NewFeedHandler(e *echo.Echo, svc FeedService, items int64) {
+	if items <= 0 {
+		items = DefaultFeedItems
+	}
+	handler := &FeedHandler{
+		Service: svc,
+		Items:   items,
+	}
+	e.GET("/feeds/articles.rss", handler.RSS)
+	e.GET("/feeds/articles.atom", handler.Atom)
+	e.GET("/feeds/authors/:id/articles.rss", handler.RSS)
+	e.GET("/feeds/authors/:id/articles.atom", handler.Atom)
+	e.GET("/feeds/categories/:id/articles.rss", handler.RSS)
+	e.GET("/feeds/categories/:id/articles.atom", handler.Atom)
+}
+
+// feed is what the RSS and the Atom documents are rendered from
+type feed struct {
+	title    string
+	link     string
+	self     string
+	updated  time.Time
+	articles []domain.Article
+}
+
+// RSS will serve the latest published articles as an RSS 2.0 feed, of everyone or of the author
+// or the category in the path
+func (h *FeedHandler) RSS(c echo.Context) error {
+	return h.serve(c, mimeApplicationRSS, renderRSS)
+}
+
+// Atom will serve the latest published articles as an Atom feed, of everyone or of the author
+// or the category in the path
+func (h *FeedHandler) Atom(c echo.Context) error {
+	return h.serve(c, mimeApplicationAtom, renderAtom)
+}
+
+func (h *FeedHandler) serve(c echo.Context, contentType string, render func(feed) interface{}) error {
+	// the latest published first, whenever they were written
+	q := domain.ArticleQuery{
+		Num:    h.Items,
+		Order:  domain.SortDesc,
+		Sort:   domain.SortByPublishAt,
+		Status: domain.StatusPublished,
+	}
+	title := "Articles"
+	ctx := c.Request().Context()
+	var err error
+	switch {
+	case strings.HasPrefix(c.Path(), "/feeds/authors/"):
+		q.AuthorID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+		if err == nil {
+			var author domain.Author
+			author, err = h.Service.GetAuthor(ctx, q.AuthorID)
+			title = "Articles by " + author.Name
+		}
+	case strings.HasPrefix(c.Path(), "/feeds/categories/"):
+		q.CategoryID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+		if err == nil {
+			var category domain.Category
+			category, err = h.Service.GetCategory(ctx, q.CategoryID)
+			title = "Articles in " + category.Name
+		}
+	}
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	articles, _, err := h.Service.Fetch(ctx, q)
+	if errors.Is(err, domain.ErrPartialResult) {
+		setWarning(c, err)
+	} else if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	base := c.Scheme() + "://" + c.Request().Host
+	f := feed{
+		title:    title,
+		link:     base + "/articles",
+		self:     base + c.Request().URL.Path,
+		articles: articles,
+	}
+	for _, ar := range articles { //nolint
+		if ar.UpdatedAt.After(f.updated) {
+			f.updated = ar.UpdatedAt
+		}
+	}
+
+	// a feed changes whenever an article comes in, goes away or gets edited
+	etag := feedETag(articles)
+	header := c.Response().Header()
+	header.Set(`ETag`, etag)
+	if !f.updated.IsZero() {
+		header.Set(`Last-Modified`, f.updated.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c.Request(), etag, f.updated) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	body, err := xml.MarshalIndent(render(f), "", "  ")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseError{Message: err.Error()})
+	}
+	return c.Blob(http.StatusOK, contentType+"; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+// feedETag derives the feed's entity tag from the articles it carries and their modification times
+func feedETag(articles []domain.Article) string {
+	hash := sha256.New()
+	for _, ar := range articles { //nolint
+		fmt.Fprintf(hash, "%d-%d;", ar.ID, ar.UpdatedAt.Unix())
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:8]) + `"`
+}
+
+// notModified tells whether the client's copy of the feed is still fresh. If-None-Match wins
+// over If-Modified-Since when both are sent
+func notModified(req *http.Request, etag string, updated time.Time) bool {
+	if match := req.Header.Get(`If-None-Match`); match != "" {
+		return etagMatches(match, etag)
+	}
+	since, err := http.ParseTime(req.Header.Get(`If-Modified-Since`))
+	if err != nil || updated.IsZero() {
+		return false
+	}
+	return !updated.Truncate(time.Second).After(since)
+}
+
+// articleLink is where the article can be read
+func articleLink(f feed, ar domain.Article) string {
+	return f.link + "/by-slug/" + ar.Slug
+}
+
+// articleGUID identifies the article in the feeds, unlike its link it survives a change of title
+func articleGUID(f feed, ar domain.Article) string {
+	return f.link + "/" + strconv.FormatInt(ar.ID, 10)
+}
+
+// publishedAt is when the article went public, its creation for the articles published before it was recorded
+func publishedAt(ar domain.Article) time.Time {
+	if ar.PublishAt != nil {
+		return *ar.PublishAt
+	}
+	return ar.CreatedAt
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Self          rssSelf   `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+func renderRSS(f feed) interface{} {
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.title,
+			Link:        f.link,
+			Self:        rssSelf{Href: f.self, Rel: "self", Type: mimeApplicationRSS},
+			Description: f.title,
+			Items:       make([]rssItem, 0, len(f.articles)),
+		},
+	}
+	if !f.updated.IsZero() {
+		doc.Channel.LastBuildDate = f.updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, ar := range f.articles { //nolint
+		item := rssItem{
+			Title:       ar.Title,
+			Link:        articleLink(f, ar),
+			GUID:        articleGUID(f, ar),
+			Author:      ar.Author.Name,
+			Description: ar.Content,
+			PubDate:     publishedAt(ar).UTC().Format(time.RFC1123Z),
+		}
+		for _, category := range ar.Categories {
+			item.Categories = append(item.Categories, category.Name)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+	return doc
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+func renderAtom(f feed) interface{} {
+	updated := f.updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+	doc := atomDocument{
+		ID:      f.self,
+		Title:   f.title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.link},
+			{Href: f.self, Rel: "self", Type: mimeApplicationAtom},
+		},
+		Entries: make([]atomEntry, 0, len(f.articles)),
+	}
+	for _, ar := range f.articles { //nolint
+		entry := atomEntry{
+			ID:        articleGUID(f, ar),
+			Title:     ar.Title,
+			Link:      atomLink{Href: articleLink(f, ar)},
+			Published: publishedAt(ar).UTC().Format(time.RFC3339),
+			Updated:   ar.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: ar.Author.Name},
+			Content:   atomContent{Type: "html", Body: ar.Content},
+		}
+		for _, category := range ar.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category.Name})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
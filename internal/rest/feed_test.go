@@ -0,0 +1,181 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func feedArticles() []domain.Article {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []domain.Article{
+		{ID: 7, Title: "Hello & welcome", Slug: "hello-welcome", Content: "<p>Content</p>", Status: domain.StatusPublished,
+			Author: domain.Author{ID: 2, Name: "Iman"}, Categories: []domain.Category{{ID: 3, Name: "Go"}},
+			CreatedAt: updatedAt.Add(-time.Hour), UpdatedAt: updatedAt},
+	}
+}
+
+func TestFeedRSS(t *testing.T) {
+	mockUCase := new(mocks.FeedService)
+	mockUCase.On("GetAuthor", mock.Anything, int64(2)).Return(domain.Author{ID: 2, Name: "Iman"}, nil).Once()
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{
+		Num: 5, Order: domain.SortDesc, Sort: domain.SortByPublishAt, Status: domain.StatusPublished, AuthorID: 2,
+	}).Return(feedArticles(), domain.Page{}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/feeds/authors/2/articles.rss", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Host = "example.com"
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/feeds/authors/:id/articles.rss")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	handler := rest.FeedHandler{
+		Service: mockUCase,
+		Items:   5,
+	}
+	err = handler.RSS(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `<title>Articles by Iman</title>`)
+	assert.Contains(t, body, `<title>Hello &amp; welcome</title>`)
+	assert.Contains(t, body, `<link>http://example.com/articles/by-slug/hello-welcome</link>`)
+	assert.Contains(t, body, `<guid>http://example.com/articles/7</guid>`)
+	assert.Contains(t, body, `<dc:creator>Iman</dc:creator>`)
+	assert.Contains(t, body, `<category>Go</category>`)
+	assert.Contains(t, body, `<pubDate>Tue, 02 Jan 2024 02:04:05 +0000</pubDate>`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFeedAtom(t *testing.T) {
+	mockUCase := new(mocks.FeedService)
+	mockUCase.On("GetCategory", mock.Anything, int64(3)).Return(domain.Category{ID: 3, Name: "Go"}, nil).Once()
+	mockUCase.On("Fetch", mock.Anything, domain.ArticleQuery{
+		Num: 5, Order: domain.SortDesc, Sort: domain.SortByPublishAt, Status: domain.StatusPublished, CategoryID: 3,
+	}).Return(feedArticles(), domain.Page{}, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/feeds/categories/3/articles.atom", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Host = "example.com"
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/feeds/categories/:id/articles.atom")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := rest.FeedHandler{
+		Service: mockUCase,
+		Items:   5,
+	}
+	err = handler.Atom(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	body := rec.Body.String()
+	assert.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, body, `<title>Articles in Go</title>`)
+	assert.Contains(t, body, `<updated>2024-01-02T03:04:05Z</updated>`)
+	assert.Contains(t, body, `<id>http://example.com/articles/7</id>`)
+	assert.Contains(t, body, `<name>Iman</name>`)
+	assert.Contains(t, body, `<content type="html">&lt;p&gt;Content&lt;/p&gt;</content>`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFeedUnknown(t *testing.T) {
+	mockUCase := new(mocks.FeedService)
+	mockUCase.On("GetAuthor", mock.Anything, int64(9)).Return(domain.Author{}, domain.ErrNotFound).Once()
+	mockUCase.On("GetCategory", mock.Anything, int64(9)).Return(domain.Category{}, domain.ErrNotFound).Once()
+	handler := rest.FeedHandler{
+		Service: mockUCase,
+		Items:   5,
+	}
+
+	tests := map[string]struct {
+		path string
+		id   string
+	}{
+		"author":       {"/feeds/authors/:id/articles.rss", "9"},
+		"category":     {"/feeds/categories/:id/articles.atom", "9"},
+		"malformed id": {"/feeds/authors/:id/articles.rss", "nine"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.TODO(), echo.GET, strings.Replace(tc.path, ":id", tc.id, 1), strings.NewReader(""))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetPath(tc.path)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+			require.NoError(t, handler.RSS(c))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		})
+	}
+	mockUCase.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFeedConditionalGet(t *testing.T) {
+	handler := rest.FeedHandler{Items: 5}
+	serve := func(header, value string) *httptest.ResponseRecorder {
+		mockUCase := new(mocks.FeedService)
+		mockUCase.On("Fetch", mock.Anything, mock.AnythingOfType("domain.ArticleQuery")).
+			Return(feedArticles(), domain.Page{}, nil).Once()
+		handler.Service = mockUCase
+
+		req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/feeds/articles.rss", strings.NewReader(""))
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetPath("/feeds/articles.rss")
+		require.NoError(t, handler.RSS(c))
+		mockUCase.AssertExpectations(t)
+		return rec
+	}
+
+	etag := serve("", "").Header().Get("ETag")
+
+	t.Run("if-none-match", func(t *testing.T) {
+		rec := serve("If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+	t.Run("if-none-match-stale", func(t *testing.T) {
+		rec := serve("If-None-Match", `"stale"`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("if-modified-since", func(t *testing.T) {
+		rec := serve("If-Modified-Since", "Tue, 02 Jan 2024 03:04:05 GMT")
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+	t.Run("modified-since", func(t *testing.T) {
+		rec := serve("If-Modified-Since", "Tue, 02 Jan 2024 03:04:04 GMT")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/bxcodec/go-clean-arch/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ArticleService is an autogenerated mock type for the ArticleService type
@@ -32,36 +34,36 @@ func (_m *ArticleService) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
-// Fetch provides a mock function with given fields: ctx, cursor, num
-func (_m *ArticleService) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Article, string, error) {
-	ret := _m.Called(ctx, cursor, num)
+// Fetch provides a mock function with given fields: ctx, q
+func (_m *ArticleService) Fetch(ctx context.Context, q domain.ArticleQuery) ([]domain.Article, domain.Page, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Fetch")
 	}
 
 	var r0 []domain.Article
-	var r1 string
+	var r1 domain.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.Article, string, error)); ok {
-		return rf(ctx, cursor, num)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ArticleQuery) ([]domain.Article, domain.Page, error)); ok {
+		return rf(ctx, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.Article); ok {
-		r0 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ArticleQuery) []domain.Article); ok {
+		r0 = rf(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Article)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) string); ok {
-		r1 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ArticleQuery) domain.Page); ok {
+		r1 = rf(ctx, q)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(domain.Page)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
-		r2 = rf(ctx, cursor, num)
+	if rf, ok := ret.Get(2).(func(context.Context, domain.ArticleQuery) error); ok {
+		r2 = rf(ctx, q)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -69,6 +71,36 @@ func (_m *ArticleService) Fetch(ctx context.Context, cursor string, num int64) (
 	return r0, r1, r2
 }
 
+// FetchTrash provides a mock function with given fields: ctx
+func (_m *ArticleService) FetchTrash(ctx context.Context) ([]domain.Article, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTrash")
+	}
+
+	var r0 []domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Article, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Article); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Article)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *ArticleService) GetByID(ctx context.Context, id int64) (domain.Article, error) {
 	ret := _m.Called(ctx, id)
@@ -97,6 +129,34 @@ func (_m *ArticleService) GetByID(ctx context.Context, id int64) (domain.Article
 	return r0, r1
 }
 
+// GetBySlug provides a mock function with given fields: ctx, slug
+func (_m *ArticleService) GetBySlug(ctx context.Context, slug string) (domain.Article, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Article, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Article); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.Article)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByTitle provides a mock function with given fields: ctx, title
 func (_m *ArticleService) GetByTitle(ctx context.Context, title string) (domain.Article, error) {
 	ret := _m.Called(ctx, title)
@@ -125,6 +185,106 @@ func (_m *ArticleService) GetByTitle(ctx context.Context, title string) (domain.
 	return r0, r1
 }
 
+// RecordView provides a mock function with given fields: ctx, ar, client
+func (_m *ArticleService) RecordView(ctx context.Context, ar domain.Article, client string) {
+	_m.Called(ctx, ar, client)
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *ArticleService) Restore(ctx context.Context, id int64) (domain.Article, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Article, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Article); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Article)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, q
+func (_m *ArticleService) Search(ctx context.Context, q domain.SearchQuery) ([]domain.SearchHit, domain.Page, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) ([]domain.SearchHit, domain.Page, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) []domain.SearchHit); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SearchQuery) domain.Page); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.SearchQuery) error); ok {
+		r2 = rf(ctx, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SemanticSearch provides a mock function with given fields: ctx, text, num
+func (_m *ArticleService) SemanticSearch(ctx context.Context, text string, num int64) ([]domain.SearchHit, error) {
+	ret := _m.Called(ctx, text, num)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SemanticSearch")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.SearchHit, error)); ok {
+		return rf(ctx, text, num)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.SearchHit); ok {
+		r0 = rf(ctx, text, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, text, num)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Store provides a mock function with given fields: _a0, _a1
 func (_m *ArticleService) Store(_a0 context.Context, _a1 *domain.Article) error {
 	ret := _m.Called(_a0, _a1)
@@ -143,6 +303,64 @@ func (_m *ArticleService) Store(_a0 context.Context, _a1 *domain.Article) error
 	return r0
 }
 
+// Transition provides a mock function with given fields: ctx, id, to, publishAt
+func (_m *ArticleService) Transition(ctx context.Context, id int64, to domain.ArticleStatus, publishAt *time.Time) (domain.Article, error) {
+	ret := _m.Called(ctx, id, to, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 domain.Article
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.ArticleStatus, *time.Time) (domain.Article, error)); ok {
+		return rf(ctx, id, to, publishAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.ArticleStatus, *time.Time) domain.Article); ok {
+		r0 = rf(ctx, id, to, publishAt)
+	} else {
+		r0 = ret.Get(0).(domain.Article)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.ArticleStatus, *time.Time) error); ok {
+		r1 = rf(ctx, id, to, publishAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Trending provides a mock function with given fields: ctx, window, num
+func (_m *ArticleService) Trending(ctx context.Context, window time.Duration, num int64) ([]domain.TrendingArticle, error) {
+	ret := _m.Called(ctx, window, num)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trending")
+	}
+
+	var r0 []domain.TrendingArticle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) ([]domain.TrendingArticle, error)); ok {
+		return rf(ctx, window, num)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) []domain.TrendingArticle); ok {
+		r0 = rf(ctx, window, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrendingArticle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int64) error); ok {
+		r1 = rf(ctx, window, num)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, ar
 func (_m *ArticleService) Update(ctx context.Context, ar *domain.Article) error {
 	ret := _m.Called(ctx, ar)
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// RevisionService represent the article revision's usecases
+//
+//go:generate mockery --name RevisionService
+type RevisionService interface {
+	FetchRevisions(ctx context.Context, articleID int64) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, articleID, revisionID int64) (domain.Revision, error)
+	DiffRevisions(ctx context.Context, articleID, fromID, toID int64) (domain.RevisionDiff, error)
+	Rollback(ctx context.Context, articleID, revisionID int64) (domain.Article, error)
+}
+
+// RevisionHandler  represent the httphandler for article revisions
+type RevisionHandler struct {
+	Service RevisionService
+}
+
+// NewRevisionHandler will initialize the articles/:id/revisions resources endpoint
+func NewRevisionHandler(e *echo.Echo, svc RevisionService) {
+	handler := &RevisionHandler{
+		Service: svc,
+	}
+	e.GET("/articles/:id/revisions", handler.FetchRevisions)
+	e.GET("/articles/:id/revisions/diff", handler.Diff)
+	e.GET("/articles/:id/revisions/:revisionID", handler.GetRevision)
+	e.POST("/articles/:id/revisions/:revisionID/rollback", handler.Rollback)
+}
+
+// FetchRevisions will fetch the revisions of the article, the latest first
+func (h *RevisionHandler) FetchRevisions(c echo.Context) error {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	list, err := h.Service.FetchRevisions(ctx, articleID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetRevision will get the revision of the article by given id
+func (h *RevisionHandler) GetRevision(c echo.Context) error {
+	articleID, revisionID, err := articleRevisionParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	revision, err := h.Service.GetRevision(ctx, articleID, revisionID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, revision)
+}
+
+// Diff will compare the from and to revisions of the article line by line
+func (h *RevisionHandler) Diff(c echo.Context) error {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	fromID, errFrom := strconv.ParseInt(c.QueryParam("from"), 10, 64)
+	toID, errTo := strconv.ParseInt(c.QueryParam("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "from and to must be revision ids"})
+	}
+
+	ctx := c.Request().Context()
+	diff, err := h.Service.DiffRevisions(ctx, articleID, fromID, toID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// Rollback will restore the article as it was in the revision, as a new revision
+func (h *RevisionHandler) Rollback(c echo.Context) error {
+	articleID, revisionID, err := articleRevisionParams(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	article, err := h.Service.Rollback(ctx, articleID, revisionID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	c.Response().Header().Set(`ETag`, articleETag(article))
+	return c.JSON(http.StatusOK, article)
+}
+
+func articleRevisionParams(c echo.Context) (articleID, revisionID int64, err error) {
+	articleID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return
+	}
+	revisionID, err = strconv.ParseInt(c.Param("revisionID"), 10, 64)
+	return
+}
@@ -0,0 +1,91 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	mockUCase := new(mocks.RevisionService)
+	mockUCase.On("DiffRevisions", mock.Anything, int64(12), int64(2), int64(5)).
+		Return(domain.RevisionDiff{From: 2, To: 5}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/12/revisions/diff?from=2&to=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/revisions/diff")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.RevisionHandler{
+		Service: mockUCase,
+	}
+	err = handler.Diff(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDiffRevisionsWithoutTo(t *testing.T) {
+	mockUCase := new(mocks.RevisionService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/12/revisions/diff?from=2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/revisions/diff")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.RevisionHandler{
+		Service: mockUCase,
+	}
+	err = handler.Diff(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRollback(t *testing.T) {
+	mockUCase := new(mocks.RevisionService)
+	updatedAt := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	mockUCase.On("Rollback", mock.Anything, int64(12), int64(2)).
+		Return(domain.Article{ID: 12, Title: "Hello", UpdatedAt: updatedAt, Version: 5}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/12/revisions/2/rollback", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/revisions/:revisionID/rollback")
+	c.SetParamNames("id", "revisionID")
+	c.SetParamValues("12", "2")
+	handler := rest.RevisionHandler{
+		Service: mockUCase,
+	}
+	err = handler.Rollback(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"12-5"`, rec.Header().Get("ETag"))
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// TagService represent the tag's usecases
+//
+//go:generate mockery --name TagService
+type TagService interface {
+	SuggestTags(ctx context.Context, prefix string, num int64) ([]domain.Tag, error)
+	SetTags(ctx context.Context, articleID int64, tags []string) ([]string, error)
+	RenameTag(ctx context.Context, id int64, name string) (domain.Tag, error)
+	MergeTags(ctx context.Context, fromID, intoID int64) (domain.Tag, error)
+}
+
+// TagHandler  represent the httphandler for tags
+type TagHandler struct {
+	Service TagService
+}
+
+// NewTagHandler will initialize the tags/ resources endpoint
+func NewTagHandler(e *echo.Echo, svc TagService) {
+	handler := &TagHandler{
+		Service: svc,
+	}
+	e.GET("/tags", handler.Suggest)
+	e.PUT("/articles/:id/tags", handler.SetTags)
+	e.PUT("/admin/tags/:id", handler.Rename)
+	e.POST("/admin/tags/:id/merge", handler.Merge)
+}
+
+// TagsRequest represent the request body replacing the tags of an article
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// RenameTagRequest represent the request body renaming a tag
+type RenameTagRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// MergeTagRequest represent the request body merging a tag into another one
+type MergeTagRequest struct {
+	Into int64 `json:"into" validate:"required"`
+}
+
+// Suggest will fetch the tags starting with the prefix param, the most used first
+func (h *TagHandler) Suggest(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := h.Service.SuggestTags(ctx, c.QueryParam("prefix"), pageSize(c))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// SetTags will replace the tags of the article by the ones in the request body
+func (h *TagHandler) SetTags(c echo.Context) error {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req TagsRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tags, err := h.Service.SetTags(ctx, articleID, req.Tags)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, TagsRequest{Tags: tags})
+}
+
+// Rename will rename the tag on every article, renaming it to an existing tag merges them
+func (h *TagHandler) Rename(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req RenameTagRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tag, err := h.Service.RenameTag(ctx, id, req.Name)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// Merge will merge the tag into the one in the request body
+func (h *TagHandler) Merge(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req MergeTagRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tag, err := h.Service.MergeTags(ctx, id, req.Into)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
@@ -0,0 +1,115 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestSuggestTags(t *testing.T) {
+	mockUCase := new(mocks.TagService)
+	mockUCase.On("SuggestTags", mock.Anything, "go", int64(5)).
+		Return([]domain.Tag{{ID: 1, Name: "go", Usage: 10}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/tags?prefix=go&num=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TagHandler{
+		Service: mockUCase,
+	}
+	err = handler.Suggest(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"id":1,"name":"go","usage":10}]`, rec.Body.String())
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSetTags(t *testing.T) {
+	mockUCase := new(mocks.TagService)
+	mockUCase.On("SetTags", mock.Anything, int64(12), []string{"Go", "mysql"}).
+		Return([]string{"go", "mysql"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/articles/12/tags",
+		strings.NewReader(`{"tags":["Go","mysql"]}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/articles/:id/tags")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := rest.TagHandler{
+		Service: mockUCase,
+	}
+	err = handler.SetTags(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"tags":["go","mysql"]}`, rec.Body.String())
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRenameTag(t *testing.T) {
+	mockUCase := new(mocks.TagService)
+	mockUCase.On("RenameTag", mock.Anything, int64(3), "go").
+		Return(domain.Tag{}, domain.ErrConflict)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.PUT, "/admin/tags/3", strings.NewReader(`{"name":"go"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/admin/tags/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := rest.TagHandler{
+		Service: mockUCase,
+	}
+	err = handler.Rename(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestMergeTagWithoutTarget(t *testing.T) {
+	mockUCase := new(mocks.TagService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/admin/tags/3/merge", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/admin/tags/:id/merge")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := rest.TagHandler{
+		Service: mockUCase,
+	}
+	err = handler.Merge(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,346 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// TransferService represent the article import and export usecases
+//
+//go:generate mockery --name TransferService
+type TransferService interface {
+	Import(ctx context.Context, ar *domain.Article, dryRun bool) error
+	Export(ctx context.Context, visit func(domain.Article) error) error
+}
+
+// TransferHandler  represent the httphandler for moving articles between environments
+type TransferHandler struct {
+	Service TransferService
+}
+
+const (
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+
+	mimeApplicationNDJSON = "application/x-ndjson"
+	mimeTextCSV           = "text/csv"
+)
+
+// articleCSVHeader are the columns of the CSV exports, the imports read them by name and only need
+// title, content and author_id. id, slug and author_name are ignored on import
+var articleCSVHeader = []string{"id", "title", "slug", "content", "status", "publish_at", "author_id", "author_name", "created_at", "updated_at"}
+
+// NewTransferHandler will initialize the articles/import and articles/export resources endpoint
+func NewTransferHandler(e *echo.Echo, svc TransferService) {
+	handler := &TransferHandler{
+		Service: svc,
+	}
+	e.POST("/articles/import", handler.Import)
+	e.GET("/articles/export", handler.Export)
+}
+
+// ImportReport represent the outcome of an import, row by row for the rows that failed.
+// Message tells why the body couldn't be read to the end, the rows reported were handled anyway
+type ImportReport struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+	Message  string           `json:"message,omitempty"`
+}
+
+// ImportRowError represent a row that couldn't be imported, rows are numbered from 1 and the
+// CSV header is row 1
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// rowReader reads the articles of an import one row at a time, it returns io.EOF after the last row.
+// A row that can't be read is reported with a rowError, the next row can still be read after it
+type rowReader interface {
+	Next() (row int, ar domain.Article, err error)
+}
+
+// rowError is a row of an import that can't be read
+type rowError struct {
+	err error
+}
+
+func (e rowError) Error() string { return e.err.Error() }
+
+// Import will store the articles of the JSON Lines or CSV request body row by row, the format param
+// or the Content-Type tells which one it is. With dry_run=true the rows are only checked. When the
+// body breaks off, the report of the rows read until then comes with a 400
+func (h *TransferHandler) Import(c echo.Context) error {
+	dryRun, err := strconv.ParseBool(c.QueryParam("dry_run"))
+	if err != nil && c.QueryParam("dry_run") != "" {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "dry_run must be a boolean"})
+	}
+
+	format := c.QueryParam("format")
+	if format == "" && strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), mimeTextCSV) {
+		format = formatCSV
+	}
+
+	var rows rowReader
+	switch format {
+	case "", formatJSONL:
+		rows = newJSONLReader(c.Request().Body)
+	case formatCSV:
+		rows, err = newCSVReader(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+		}
+	default:
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "format must be jsonl or csv"})
+	}
+
+	ctx := c.Request().Context()
+	report := ImportReport{DryRun: dryRun, Errors: []ImportRowError{}}
+	for {
+		row, ar, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var badRow rowError
+		if err != nil && !errors.As(err, &badRow) {
+			report.Message = err.Error()
+			return c.JSON(http.StatusBadRequest, report)
+		}
+
+		report.Total++
+		if err == nil {
+			_, err = isRequestValid(&ar)
+		}
+		if err == nil {
+			err = h.Service.Import(ctx, &ar, dryRun)
+		}
+		if err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, ImportRowError{Row: row, Message: err.Error()})
+			continue
+		}
+		report.Imported++
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// Export will stream every article along with its author, as JSON Lines or as CSV by the format param
+func (h *TransferHandler) Export(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = formatJSONL
+	}
+
+	var write func(domain.Article) error
+	resp := c.Response()
+	switch format {
+	case formatJSONL:
+		resp.Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+		enc := json.NewEncoder(resp)
+		write = func(ar domain.Article) error {
+			return enc.Encode(ar)
+		}
+	case formatCSV:
+		resp.Header().Set(echo.HeaderContentType, mimeTextCSV+"; charset=utf-8")
+		w := csv.NewWriter(resp)
+		write = func(ar domain.Article) error {
+			if !resp.Committed {
+				if err := w.Write(articleCSVHeader); err != nil {
+					return err
+				}
+			}
+			if err := w.Write(articleToCSV(ar)); err != nil {
+				return err
+			}
+			w.Flush()
+			return w.Error()
+		}
+	default:
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: "format must be jsonl or csv"})
+	}
+	resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="articles.%s"`, format))
+
+	ctx := c.Request().Context()
+	err := h.Service.Export(ctx, func(ar domain.Article) error {
+		err := write(ar)
+		if err != nil {
+			return err
+		}
+		resp.Flush()
+		return nil
+	})
+	if err != nil {
+		if !resp.Committed {
+			return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		}
+		// the status is gone already, the client gets a truncated export
+		logrus.Errorf("export aborted: %s", err)
+		return nil
+	}
+
+	if !resp.Committed {
+		if format == formatCSV {
+			w := csv.NewWriter(resp)
+			_ = w.Write(articleCSVHeader) // reported by w.Error
+			w.Flush()
+			return w.Error()
+		}
+		resp.WriteHeader(http.StatusOK)
+	}
+	return nil
+}
+
+// jsonlReader reads an article off every non-blank line
+type jsonlReader struct {
+	r   *bufio.Reader
+	row int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	return &jsonlReader{r: bufio.NewReader(r)}
+}
+
+func (j *jsonlReader) Next() (int, domain.Article, error) {
+	for {
+		line, err := j.r.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return j.row, domain.Article{}, err
+		}
+		j.row++
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var ar domain.Article
+		if errDecode := json.Unmarshal(line, &ar); errDecode != nil {
+			return j.row, domain.Article{}, rowError{errDecode}
+		}
+		return j.row, ar, nil
+	}
+}
+
+// csvReader reads an article off every record, the columns are found by the names of the header
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("the CSV header can't be read: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"title", "content", "author_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("the CSV header misses the %s column", required)
+		}
+	}
+	return &csvReader{r: reader, columns: columns, row: 1}, nil
+}
+
+func (c *csvReader) Next() (int, domain.Article, error) {
+	record, err := c.r.Read()
+	c.row++
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return c.row, domain.Article{}, rowError{err}
+	}
+	if err != nil {
+		return c.row, domain.Article{}, err
+	}
+
+	ar, err := articleFromCSV(c.columns, record)
+	if err != nil {
+		return c.row, domain.Article{}, rowError{err}
+	}
+	return c.row, ar, nil
+}
+
+// articleToCSV lays the article out along articleCSVHeader
+func articleToCSV(ar domain.Article) []string {
+	publishAt := ""
+	if ar.PublishAt != nil {
+		publishAt = ar.PublishAt.Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(ar.ID, 10),
+		ar.Title,
+		ar.Slug,
+		ar.Content,
+		string(ar.Status),
+		publishAt,
+		strconv.FormatInt(ar.Author.ID, 10),
+		ar.Author.Name,
+		ar.CreatedAt.Format(time.RFC3339),
+		ar.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// articleFromCSV reads the article off a record, the empty optional columns are left unset
+func articleFromCSV(columns map[string]int, record []string) (ar domain.Article, err error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	ar.Title = field("title")
+	ar.Content = field("content")
+	ar.Status = domain.ArticleStatus(field("status"))
+	ar.Author.ID, err = strconv.ParseInt(field("author_id"), 10, 64)
+	if err != nil {
+		return domain.Article{}, fmt.Errorf("author_id must be an id")
+	}
+
+	for _, column := range []struct {
+		name string
+		at   *time.Time
+	}{
+		{"created_at", &ar.CreatedAt},
+		{"updated_at", &ar.UpdatedAt},
+	} {
+		if value := field(column.name); value != "" {
+			*column.at, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				return domain.Article{}, fmt.Errorf("%s must be an RFC3339 time", column.name)
+			}
+		}
+	}
+	if value := field("publish_at"); value != "" {
+		publishAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return domain.Article{}, fmt.Errorf("publish_at must be an RFC3339 time")
+		}
+		ar.PublishAt = &publishAt
+	}
+	return ar, nil
+}
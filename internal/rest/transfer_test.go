@@ -0,0 +1,220 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/internal/rest"
+	"github.com/bxcodec/go-clean-arch/internal/rest/mocks"
+)
+
+func TestImportJSONL(t *testing.T) {
+	mockUCase := new(mocks.TransferService)
+	mockUCase.On("Import", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.Title == "first" && ar.Author.ID == 1 && ar.Status == domain.StatusPublished
+	}), false).Return(nil).Once()
+	mockUCase.On("Import", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.Title == "taken"
+	}), false).Return(domain.ErrConflict).Once()
+
+	body := `{"title":"first","content":"hello","author":{"id":1},"status":"published"}
+
+{"title":"broken",
+{"title":"taken","content":"hello","author":{"id":1}}
+{"title":"","content":"no title","author":{"id":1}}
+`
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/import", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TransferHandler{
+		Service: mockUCase,
+	}
+	err = handler.Import(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"total":4,"imported":1,"failed":3`)
+	assert.Contains(t, rec.Body.String(), `"row":3`)
+	assert.Contains(t, rec.Body.String(), `{"row":4,"message":"your Item already exist"}`)
+	assert.Contains(t, rec.Body.String(), `"row":5`)
+	mockUCase.AssertExpectations(t)
+}
+
+// brokenReader fails once its data is read, like a connection cut in the middle of an upload
+type brokenReader struct {
+	r io.Reader
+}
+
+func (b *brokenReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func TestImportBrokenBody(t *testing.T) {
+	mockUCase := new(mocks.TransferService)
+	mockUCase.On("Import", mock.Anything, mock.AnythingOfType("*domain.Article"), false).Return(nil).Twice()
+
+	body := `{"title":"first","content":"hello","author":{"id":1}}
+{"title":"second","content":"hello","author":{"id":1}}
+`
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/import",
+		&brokenReader{strings.NewReader(body)})
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TransferHandler{
+		Service: mockUCase,
+	}
+	err = handler.Import(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"dry_run":false,"total":2,"imported":2,"failed":0,"errors":[],
+		"message":"connection reset"}`, rec.Body.String())
+	mockUCase.AssertExpectations(t)
+}
+
+func TestImportCSVDryRun(t *testing.T) {
+	mockUCase := new(mocks.TransferService)
+	mockUCase.On("Import", mock.Anything, mock.MatchedBy(func(ar *domain.Article) bool {
+		return ar.Title == "first" && ar.Content == "hello, world" && ar.Author.ID == 2 &&
+			ar.Status == domain.StatusScheduled && ar.PublishAt != nil
+	}), true).Return(nil).Once()
+
+	body := "title,content,author_id,status,publish_at\n" +
+		"first,\"hello, world\",2,scheduled,2030-01-02T15:04:05Z\n" +
+		"second,hello,nobody,draft,\n"
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/import?dry_run=true", strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TransferHandler{
+		Service: mockUCase,
+	}
+	err = handler.Import(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"dry_run":true,"total":2,"imported":1,"failed":1,
+		"errors":[{"row":3,"message":"author_id must be an id"}]}`, rec.Body.String())
+	mockUCase.AssertExpectations(t)
+}
+
+func TestImportBadCSVHeader(t *testing.T) {
+	mockUCase := new(mocks.TransferService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.POST, "/articles/import?format=csv",
+		strings.NewReader("title,content\nfirst,hello\n"))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TransferHandler{
+		Service: mockUCase,
+	}
+	err = handler.Import(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExport(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	articles := []domain.Article{
+		{ID: 1, Title: "first", Slug: "first", Content: "hello, world", Status: domain.StatusPublished,
+			Author: domain.Author{ID: 2, Name: "Iman"}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, Title: "second", Slug: "second", Content: "hello", Status: domain.StatusDraft,
+			Author: domain.Author{ID: 2, Name: "Iman"}, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	for _, tc := range []struct {
+		format      string
+		contentType string
+		want        string
+	}{
+		{
+			format:      "csv",
+			contentType: "text/csv; charset=utf-8",
+			want: "id,title,slug,content,status,publish_at,author_id,author_name,created_at,updated_at\n" +
+				"1,first,first,\"hello, world\",published,,2,Iman,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z\n" +
+				"2,second,second,hello,draft,,2,Iman,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z\n",
+		},
+		{
+			format:      "jsonl",
+			contentType: "application/x-ndjson",
+			want: `{"id":1,"title":"first","slug":"first","content":"hello, world","author":{"id":2,"name":"Iman","created_at":"","updated_at":""},"status":"published","updated_at":"2024-01-02T03:04:05Z","created_at":"2024-01-02T03:04:05Z","version":0,"word_count":0,"reading_minutes":0,"excerpt":""}` + "\n" +
+				`{"id":2,"title":"second","slug":"second","content":"hello","author":{"id":2,"name":"Iman","created_at":"","updated_at":""},"status":"draft","updated_at":"2024-01-02T03:04:05Z","created_at":"2024-01-02T03:04:05Z","version":0,"word_count":0,"reading_minutes":0,"excerpt":""}` + "\n",
+		},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			mockUCase := new(mocks.TransferService)
+			mockUCase.On("Export", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				visit := args.Get(1).(func(domain.Article) error)
+				for _, ar := range articles {
+					require.NoError(t, visit(ar))
+				}
+			}).Return(nil).Once()
+
+			e := echo.New()
+			req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/export?format="+tc.format, strings.NewReader(""))
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := rest.TransferHandler{
+				Service: mockUCase,
+			}
+			err = handler.Export(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.contentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tc.want, rec.Body.String())
+			mockUCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportBadFormat(t *testing.T) {
+	mockUCase := new(mocks.TransferService)
+
+	e := echo.New()
+	req, err := http.NewRequestWithContext(context.TODO(), echo.GET, "/articles/export?format=xml", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := rest.TransferHandler{
+		Service: mockUCase,
+	}
+	err = handler.Export(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Export", mock.Anything, mock.Anything)
+}
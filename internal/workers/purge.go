@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ArticleTrash represent the article's trash usecases
+//
+//go:generate mockery --name ArticleTrash
+type ArticleTrash interface {
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+}
+
+// BMITrash represent the BMI record's trash usecases
+//
+//go:generate mockery --name BMITrash
+type BMITrash interface {
+	PurgeTrashedBMI(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Purger permanently removes the articles and BMI records that stayed in the trash longer than the retention window
+type Purger struct {
+	articles  ArticleTrash
+	bmi       BMITrash
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewPurger will create a purger keeping the trashed items for the retention window
+func NewPurger(articles ArticleTrash, bmi BMITrash, retention time.Duration) *Purger {
+	return &Purger{
+		articles:  articles,
+		bmi:       bmi,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Run purges right away and then every interval, until the context is done
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := p.Purge(ctx)
+		if err != nil {
+			logrus.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes the items trashed before the retention window, a failure on
+// the articles doesn't keep the BMI records from being purged
+func (p *Purger) Purge(ctx context.Context) error {
+	before := p.now().Add(-p.retention)
+
+	articles, errArticles := p.articles.PurgeTrash(ctx, before)
+	if errArticles == nil && articles > 0 {
+		logrus.Infof("purged %d articles trashed before %s", articles, before.Format(time.RFC3339))
+	}
+
+	records, errBMI := p.bmi.PurgeTrashedBMI(ctx, before)
+	if errBMI == nil && records > 0 {
+		logrus.Infof("purged %d BMI records trashed before %s", records, before.Format(time.RFC3339))
+	}
+
+	return errors.Join(errArticles, errBMI)
+}
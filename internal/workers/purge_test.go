@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/internal/workers/mocks"
+)
+
+func TestPurge(t *testing.T) {
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+	before := now.Add(-30 * 24 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticles := new(mocks.ArticleTrash)
+		mockArticles.On("PurgeTrash", context.TODO(), before).Return(int64(2), nil).Once()
+		mockBMI := new(mocks.BMITrash)
+		mockBMI.On("PurgeTrashedBMI", context.TODO(), before).Return(int64(0), nil).Once()
+
+		p := NewPurger(mockArticles, mockBMI, 30*24*time.Hour)
+		p.now = func() time.Time { return now }
+
+		assert.NoError(t, p.Purge(context.TODO()))
+		mockArticles.AssertExpectations(t)
+		mockBMI.AssertExpectations(t)
+	})
+
+	t.Run("article-failure-still-purges-bmi", func(t *testing.T) {
+		mockArticles := new(mocks.ArticleTrash)
+		mockArticles.On("PurgeTrash", context.TODO(), before).Return(int64(0), errors.New("Unexpected Error")).Once()
+		mockBMI := new(mocks.BMITrash)
+		mockBMI.On("PurgeTrashedBMI", context.TODO(), before).Return(int64(1), nil).Once()
+
+		p := NewPurger(mockArticles, mockBMI, 30*24*time.Hour)
+		p.now = func() time.Time { return now }
+
+		assert.Error(t, p.Purge(context.TODO()))
+		mockArticles.AssertExpectations(t)
+		mockBMI.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ArticlePublisher represent the article's scheduled publishing usecase
+//
+//go:generate mockery --name ArticlePublisher
+type ArticlePublisher interface {
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Scheduler publishes the scheduled articles once their publication time has come
+type Scheduler struct {
+	articles ArticlePublisher
+	now      func() time.Time
+}
+
+// NewScheduler will create a scheduler publishing through the given usecase
+func NewScheduler(articles ArticlePublisher) *Scheduler {
+	return &Scheduler{
+		articles: articles,
+		now:      time.Now,
+	}
+}
+
+// Run publishes the due articles right away and then every interval, until the context is done.
+// An article is published at most one interval late
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Publish(ctx)
+		if err != nil {
+			logrus.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish publishes the articles due by now
+func (s *Scheduler) Publish(ctx context.Context) error {
+	total, err := s.articles.PublishDue(ctx, s.now())
+	if total > 0 {
+		logrus.Infof("published %d scheduled articles", total)
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/internal/workers/mocks"
+)
+
+func TestPublish(t *testing.T) {
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticles := new(mocks.ArticlePublisher)
+		mockArticles.On("PublishDue", context.TODO(), now).Return(int64(2), nil).Once()
+
+		s := NewScheduler(mockArticles)
+		s.now = func() time.Time { return now }
+
+		assert.NoError(t, s.Publish(context.TODO()))
+		mockArticles.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockArticles := new(mocks.ArticlePublisher)
+		mockArticles.On("PublishDue", context.TODO(), now).Return(int64(1), errors.New("Unexpected Error")).Once()
+
+		s := NewScheduler(mockArticles)
+		s.now = func() time.Time { return now }
+
+		assert.Error(t, s.Publish(context.TODO()))
+		mockArticles.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,118 @@
+package workers
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultViewDedupWindow is how long a client reading an article again isn't counted again
+const DefaultViewDedupWindow = 30 * time.Minute
+
+// ViewStore represent the storage the article views are added up in
+//
+//go:generate mockery --name ViewStore
+type ViewStore interface {
+	AddViews(ctx context.Context, at time.Time, counts map[int64]int64) error
+}
+
+// viewer is a client having read an article
+type viewer struct {
+	articleID int64
+	client    uint64
+}
+
+// ViewCounter counts the article views in memory and adds them to the store in batches, so
+// recording a view never waits for the database. The views of a process that dies before
+// flushing are lost
+type ViewCounter struct {
+	store       ViewStore
+	dedupWindow time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	pending map[int64]int64
+	// seen is when each client last got a view counted, they're forgotten once the window is over
+	seen map[viewer]time.Time
+}
+
+// NewViewCounter will create a counter adding the views to the store, a client reading the same
+// article again within the dedup window counts once
+func NewViewCounter(store ViewStore, dedupWindow time.Duration) *ViewCounter {
+	return &ViewCounter{
+		store:       store,
+		dedupWindow: dedupWindow,
+		now:         time.Now,
+		pending:     make(map[int64]int64),
+		seen:        make(map[viewer]time.Time),
+	}
+}
+
+// Record counts a view of the article by the client, which is whatever tells the readers apart
+func (v *ViewCounter) Record(articleID int64, client string) {
+	hash := fnv.New64a()
+	hash.Write([]byte(client))
+	key := viewer{articleID: articleID, client: hash.Sum64()}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := v.now()
+	if last, ok := v.seen[key]; ok && now.Sub(last) < v.dedupWindow {
+		return
+	}
+	v.seen[key] = now
+	v.pending[articleID]++
+}
+
+// Run flushes the views every interval until the context is done, then flushes them a last time
+func (v *ViewCounter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			err := v.Flush(context.WithoutCancel(ctx))
+			if err != nil {
+				logrus.Error(err)
+			}
+			return
+		case <-ticker.C:
+		}
+
+		err := v.Flush(ctx)
+		if err != nil {
+			logrus.Error(err)
+		}
+	}
+}
+
+// Flush adds the views counted so far to the store. When the store fails they're kept for the next flush
+func (v *ViewCounter) Flush(ctx context.Context) error {
+	v.mu.Lock()
+	counts := v.pending
+	v.pending = make(map[int64]int64)
+	now := v.now()
+	for key, last := range v.seen {
+		if now.Sub(last) >= v.dedupWindow {
+			delete(v.seen, key)
+		}
+	}
+	v.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+	err := v.store.AddViews(ctx, now, counts)
+	if err != nil {
+		v.mu.Lock()
+		for id, n := range counts {
+			v.pending[id] += n
+		}
+		v.mu.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/internal/workers/mocks"
+)
+
+func TestViewCounter(t *testing.T) {
+	now := time.Date(2024, time.November, 17, 15, 16, 15, 0, time.UTC)
+
+	t.Run("dedup", func(t *testing.T) {
+		mockStore := new(mocks.ViewStore)
+		mockStore.On("AddViews", context.TODO(), now, map[int64]int64{1: 2, 2: 1}).Return(nil).Once()
+
+		v := NewViewCounter(mockStore, time.Minute)
+		v.now = func() time.Time { return now }
+		v.Record(1, "192.0.2.1 firefox")
+		v.Record(1, "192.0.2.1 firefox")
+		v.Record(1, "192.0.2.2 firefox")
+		v.Record(2, "192.0.2.1 firefox")
+
+		assert.NoError(t, v.Flush(context.TODO()))
+		// nothing left to flush
+		assert.NoError(t, v.Flush(context.TODO()))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("dedup-window-over", func(t *testing.T) {
+		mockStore := new(mocks.ViewStore)
+		mockStore.On("AddViews", context.TODO(), mock.Anything, map[int64]int64{1: 1}).Return(nil).Twice()
+
+		v := NewViewCounter(mockStore, time.Minute)
+		v.now = func() time.Time { return now }
+		v.Record(1, "192.0.2.1 firefox")
+		assert.NoError(t, v.Flush(context.TODO()))
+
+		v.now = func() time.Time { return now.Add(time.Minute) }
+		v.Record(1, "192.0.2.1 firefox")
+		assert.NoError(t, v.Flush(context.TODO()))
+		assert.Len(t, v.seen, 1)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("store-error", func(t *testing.T) {
+		mockStore := new(mocks.ViewStore)
+		mockStore.On("AddViews", context.TODO(), now, map[int64]int64{1: 1}).Return(errors.New("Unexpected Error")).Once()
+		mockStore.On("AddViews", context.TODO(), now, map[int64]int64{1: 2}).Return(nil).Once()
+
+		v := NewViewCounter(mockStore, time.Minute)
+		v.now = func() time.Time { return now }
+		v.Record(1, "192.0.2.1 firefox")
+		assert.Error(t, v.Flush(context.TODO()))
+
+		// the views that failed are flushed along with the new ones
+		v.Record(1, "192.0.2.2 firefox")
+		assert.NoError(t, v.Flush(context.TODO()))
+		mockStore.AssertExpectations(t)
+	})
+}